@@ -0,0 +1,215 @@
+// Package configload loads the configuration of the services from a YAML file,
+// environment variables and command line flags.
+package configload
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Configuration structs are described with struct tags:
+//
+//	yaml:   key of the field in the configuration file
+//	env:    environment variable overriding the file
+//	flag:   command line flag overriding the environment
+//	usage:  description of the flag
+//	secret: if "true", the value is redacted by --print-config
+//
+// Fields may be strings, bools, ints, floats, durations, comma separated string
+// lists or nested configuration structs.
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Validator is implemented by configuration structs.
+type Validator interface {
+	Validate() error
+}
+
+// Load fills cfg, a pointer to a configuration struct holding the
+// defaults, from the YAML file given by --config (or CONFIG_FILE), environment
+// variables and command line flags, in increasing order of precedence, and
+// validates the result. If --print-config is given, the configuration is
+// written to out and printed is true.
+func Load(cfg Validator, args []string, out io.Writer) (printed bool, err error) {
+	return LoadWithFlags(cfg, flag.NewFlagSet(args[0], flag.ContinueOnError), args[1:], out)
+}
+
+// LoadWithFlags is Load for a command with flags of its own, which
+// are registered on fs and parsed from args along with the configuration flags.
+func LoadWithFlags(cfg Validator, fs *flag.FlagSet, args []string, out io.Writer) (printed bool, err error) {
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path of the YAML configuration file")
+	printConfig := fs.Bool("print-config", false, "print the effective configuration and exit")
+
+	// Flags are collected while parsing and applied after the file and
+	// environment, which they override.
+	type flagValue struct {
+		field reflect.Value
+		value string
+	}
+	var flagValues []flagValue
+	root := reflect.ValueOf(cfg).Elem()
+	walkConfig(root, func(field reflect.Value, tag reflect.StructTag) {
+		if name := tag.Get("flag"); name != "" {
+			fs.Func(name, tag.Get("usage"), func(value string) error {
+				if err := setConfigField(reflect.New(field.Type()).Elem(), value); err != nil {
+					return err
+				}
+				flagValues = append(flagValues, flagValue{field, value})
+				return nil
+			})
+		}
+	})
+	if err := fs.Parse(args); err != nil {
+		return false, err
+	}
+
+	if *configFile != "" {
+		data, err := os.ReadFile(*configFile)
+		if err != nil {
+			return false, err
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return false, fmt.Errorf("error parsing %s: %w", *configFile, err)
+		}
+	}
+
+	var envErr error
+	walkConfig(root, func(field reflect.Value, tag reflect.StructTag) {
+		if key := tag.Get("env"); key != "" && envErr == nil {
+			if value, ok := os.LookupEnv(key); ok && value != "" {
+				if err := setConfigField(field, value); err != nil {
+					envErr = fmt.Errorf("invalid %s: %w", key, err)
+				}
+			}
+		}
+	})
+	if envErr != nil {
+		return false, envErr
+	}
+
+	for _, value := range flagValues {
+		setConfigField(value.field, value.value)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return false, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if *printConfig {
+		return true, Print(cfg, out)
+	}
+	return false, nil
+}
+
+// Print writes cfg to out as YAML, redacting secrets.
+func Print(cfg interface{}, out io.Writer) error {
+	encoder := yaml.NewEncoder(out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(configNode(reflect.ValueOf(cfg).Elem())); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func configNode(v reflect.Value) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i < v.NumField(); i++ {
+		field, structField := v.Field(i), v.Type().Field(i)
+		key := strings.Split(structField.Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+
+		var value *yaml.Node
+		switch {
+		case field.Kind() == reflect.Struct:
+			value = configNode(field)
+		case structField.Tag.Get("secret") == "true":
+			redacted := ""
+			if !field.IsZero() {
+				redacted = "REDACTED"
+			}
+			value = &yaml.Node{Kind: yaml.ScalarNode, Value: redacted}
+		case field.Type() == durationType:
+			value = &yaml.Node{Kind: yaml.ScalarNode, Value: time.Duration(field.Int()).String()}
+		default:
+			value = &yaml.Node{}
+			value.Encode(field.Interface())
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	}
+	return node
+}
+
+func walkConfig(v reflect.Value, fn func(field reflect.Value, tag reflect.StructTag)) {
+	for i := 0; i < v.NumField(); i++ {
+		field, structField := v.Field(i), v.Type().Field(i)
+		if field.Kind() == reflect.Struct {
+			walkConfig(field, fn)
+			continue
+		}
+		fn(field, structField.Tag)
+	}
+}
+
+func setConfigField(field reflect.Value, value string) error {
+	if field.Type() == durationType {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(duration))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+	case reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(parsed)
+	case reflect.Slice:
+		field.Set(reflect.ValueOf(splitList(value)))
+	default:
+		return fmt.Errorf("unsupported configuration field type %s", field.Type())
+	}
+	return nil
+}
+
+// splitList splits a comma separated list, dropping empty items.
+func splitList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
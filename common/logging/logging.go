@@ -0,0 +1,75 @@
+// Package logging sets up structured logging for the services and tags every
+// record with the request and trace it was logged for.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// RequestIDMetaKey is the key of the request ID the http-server assigns to
+// every HTTP request and sends along with its RPCs through Kitex metainfo.
+const RequestIDMetaKey = "IMSERVICE_REQUEST_ID"
+
+// Init installs a structured logger as the default for both slog and the
+// standard log package. The level is one of debug, info, warn or error and the
+// format one of json or text.
+func Init(level string, format string) {
+	var slogLevel slog.Level
+	if err := slogLevel.UnmarshalText([]byte(level)); err != nil {
+		panic(fmt.Sprintf("invalid log level: %s", level))
+	}
+
+	opts := &slog.HandlerOptions{Level: slogLevel}
+	var handler slog.Handler
+	switch format = strings.ToLower(format); format {
+	case "", "json":
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	case "text":
+		handler = slog.NewTextHandler(os.Stderr, opts)
+	default:
+		panic(fmt.Sprintf("invalid log format: %s", format))
+	}
+	slog.SetDefault(slog.New(&contextHandler{handler}))
+}
+
+// GetRequestID returns the request ID carried by ctx, or an empty string if
+// there is none.
+func GetRequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := metainfo.GetValue(ctx, RequestIDMetaKey)
+	return requestID
+}
+
+// contextHandler adds the request ID and trace ID in the context of each record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if requestID := GetRequestID(ctx); requestID != "" {
+		r.AddAttrs(slog.String("request_id", requestID))
+	}
+	if ctx != nil {
+		if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+			r.AddAttrs(slog.String("trace_id", spanContext.TraceID().String()))
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,37 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+	"golang.org/x/exp/slog"
+)
+
+func TestContextHandler_RequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(&contextHandler{slog.NewJSONHandler(&buf, nil)})
+
+	ctx := metainfo.WithValue(context.Background(), RequestIDMetaKey, "4f1c2a")
+	logger.InfoCtx(ctx, "with request")
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("unexpected error decoding record: %v", err)
+	}
+	if record["msg"] != "with request" || record["request_id"] != "4f1c2a" {
+		t.Errorf("expected the request ID in the record, got %v", record)
+	}
+
+	buf.Reset()
+	logger.InfoCtx(context.Background(), "without request")
+	record = nil
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("unexpected error decoding record: %v", err)
+	}
+	if _, ok := record["request_id"]; ok {
+		t.Errorf("expected no request ID in the record, got %v", record)
+	}
+}
@@ -0,0 +1,41 @@
+package s2s
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSignVerify(t *testing.T) {
+	secret := []byte("test-secret")
+	md := Metadata{Service: "http-server", User: "a", Method: "Send", Timestamp: "1"}
+	body := []byte("request")
+	signature := Sign(secret, md, body)
+
+	tests := []struct {
+		name   string
+		secret []byte
+		md     Metadata
+		body   []byte
+		valid  bool
+	}{
+		{"same request", secret, md, body, true},
+		{"other secret", []byte("wrong"), md, body, false},
+		{"other user", secret, Metadata{Service: "http-server", User: "b", Method: "Send", Timestamp: "1"}, body, false},
+		{"other method", secret, Metadata{Service: "http-server", User: "a", Method: "Pull", Timestamp: "1"}, body, false},
+		{"other timestamp", secret, Metadata{Service: "http-server", User: "a", Method: "Send", Timestamp: "2"}, body, false},
+		{"other request", secret, md, []byte("other request"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if valid := Verify(tt.secret, tt.md, tt.body, signature); valid != tt.valid {
+				t.Errorf("expected valid: %v, got: %v", tt.valid, valid)
+			}
+		})
+	}
+}
+
+func TestSerializeRequest(t *testing.T) {
+	if _, err := SerializeRequest(context.Background(), "not a thrift struct"); err == nil {
+		t.Error("expected an error serializing a request which is not a thrift struct")
+	}
+}
@@ -0,0 +1,73 @@
+// Package tracing sets up OpenTelemetry for the services and carries trace
+// context between them in Kitex metainfo.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+// Init installs the global tracer provider and W3C trace context propagator.
+// The exporter is one of "otlp" (configured through the standard
+// OTEL_EXPORTER_OTLP_* variables), "stdout" or "none". The returned function
+// flushes and stops the exporter.
+func Init(serviceName string, exporter string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var spanExporter sdktrace.SpanExporter
+	var err error
+	switch exporterName := strings.ToLower(exporter); exporterName {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		spanExporter, err = otlptracehttp.New(context.Background())
+	case "stdout":
+		spanExporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unknown trace exporter: %s", exporterName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// MetainfoCarrier carries trace context in Kitex metainfo, which travels in
+// TTHeader. Keys are upper-cased as metainfo transient keys are. Injecting
+// replaces Ctx with a context carrying the new values.
+type MetainfoCarrier struct {
+	Ctx context.Context
+}
+
+func (m *MetainfoCarrier) Get(key string) string {
+	value, _ := metainfo.GetValue(m.Ctx, strings.ToUpper(key))
+	return value
+}
+
+func (m *MetainfoCarrier) Set(key string, value string) {
+	m.Ctx = metainfo.WithValue(m.Ctx, strings.ToUpper(key), value)
+}
+
+func (m *MetainfoCarrier) Keys() []string {
+	var keys []string
+	for key := range metainfo.GetAllValues(m.Ctx) {
+		keys = append(keys, strings.ToLower(key))
+	}
+	return keys
+}
@@ -0,0 +1,39 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestMetainfoCarrier(t *testing.T) {
+	traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	propagator := propagation.TraceContext{}
+
+	in := &MetainfoCarrier{Ctx: context.Background()}
+	in.Set("traceparent", traceparent)
+	ctx := propagator.Extract(context.Background(), in)
+
+	out := &MetainfoCarrier{Ctx: context.Background()}
+	propagator.Inject(ctx, out)
+	if value := out.Get("traceparent"); value != traceparent {
+		t.Errorf("expected traceparent %q, got %q", traceparent, value)
+	}
+	if keys := out.Keys(); len(keys) != 1 || keys[0] != "traceparent" {
+		t.Errorf("expected keys [traceparent], got %v", keys)
+	}
+}
+
+func TestInit(t *testing.T) {
+	shutdown, err := Init("test", "none")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("unexpected error shutting down: %v", err)
+	}
+	if _, err := Init("test", "zipkin"); err == nil {
+		t.Error("expected an error for an unknown exporter")
+	}
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/kitex_gen/rpc"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const principalContextKey = "principal"
+
+type principalCtxKeyType struct{}
+
+var (
+	errNoCredentials      = errors.New("no credentials provided")
+	errInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Principal is the authenticated identity of the caller of the HTTP API.
+type Principal struct {
+	ID     string // user or service identifier, compared against message senders and chat members
+	Method string // authentication method used, e.g. "jwt" or "apikey"
+}
+
+// Authenticator derives the caller identity from an incoming request. It
+// returns errNoCredentials if the request carries no credentials it
+// understands so that other authenticators in a chain may be tried.
+type Authenticator interface {
+	Authenticate(ctx context.Context, c *app.RequestContext) (*Principal, error)
+}
+
+// ChainAuthenticator tries each authenticator in order, returning the first
+// principal found.
+type ChainAuthenticator []Authenticator
+
+func (chain ChainAuthenticator) Authenticate(ctx context.Context, c *app.RequestContext) (*Principal, error) {
+	for _, authenticator := range chain {
+		principal, err := authenticator.Authenticate(ctx, c)
+		if errors.Is(err, errNoCredentials) {
+			continue
+		}
+		return principal, err
+	}
+	return nil, errNoCredentials
+}
+
+// JWTAuthenticator validates bearer tokens against a locally configured key set.
+type JWTAuthenticator struct {
+	keys     map[string]interface{} // key ID -> verification key
+	issuer   string
+	audience string
+	claim    string
+}
+
+func NewJWTAuthenticator(keys map[string]interface{}, issuer, audience, claim string) *JWTAuthenticator {
+	if claim == "" {
+		claim = "sub"
+	}
+	return &JWTAuthenticator{keys: keys, issuer: issuer, audience: audience, claim: claim}
+}
+
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, c *app.RequestContext) (*Principal, error) {
+	header := string(c.GetHeader("Authorization"))
+	if !strings.HasPrefix(header, "Bearer ") {
+		return nil, errNoCredentials
+	}
+
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(strings.TrimPrefix(header, "Bearer "), claims, a.keyFunc)
+	if err != nil || !token.Valid {
+		return nil, errInvalidCredentials
+	}
+
+	// ParseWithClaims only checks the expiry if present, and a token without
+	// one would be valid forever
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errInvalidCredentials
+	}
+
+	if a.issuer != "" && !claims.VerifyIssuer(a.issuer, true) {
+		return nil, errInvalidCredentials
+	}
+	if a.audience != "" && !claims.VerifyAudience(a.audience, true) {
+		return nil, errInvalidCredentials
+	}
+
+	id, _ := claims[a.claim].(string)
+	if id == "" {
+		return nil, errInvalidCredentials
+	}
+	return &Principal{ID: id, Method: "jwt"}, nil
+}
+
+func (a *JWTAuthenticator) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := a.keys[kid]
+	if !ok && kid == "" && len(a.keys) == 1 {
+		for _, onlyKey := range a.keys {
+			key, ok = onlyKey, true
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	// Ensure the signing method matches the key type so an attacker cannot,
+	// for example, sign a token with HMAC using a public key as the secret.
+	switch key.(type) {
+	case *rsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			if _, ok := token.Method.(*jwt.SigningMethodRSAPSS); !ok {
+				return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+			}
+		}
+	case *ecdsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+		}
+	case []byte:
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+		}
+	}
+	return key, nil
+}
+
+// APIKeyAuthenticator authenticates services using static API keys passed in
+// the X-API-Key header.
+type APIKeyAuthenticator struct {
+	keys map[string]string // API key -> identity
+}
+
+func NewAPIKeyAuthenticator(keys map[string]string) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{keys: keys}
+}
+
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, c *app.RequestContext) (*Principal, error) {
+	apiKey := c.GetHeader("X-API-Key")
+	if len(apiKey) == 0 {
+		return nil, errNoCredentials
+	}
+
+	for key, id := range a.keys {
+		if subtle.ConstantTimeCompare(apiKey, []byte(key)) == 1 {
+			return &Principal{ID: id, Method: "apikey"}, nil
+		}
+	}
+	return nil, errInvalidCredentials
+}
+
+// AuthMiddleware rejects requests which cannot be authenticated and stores
+// the derived principal in the request context for handlers.
+func AuthMiddleware(authenticator Authenticator) app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		principal, err := authenticator.Authenticate(ctx, c)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="imservice"`)
+			writeError(ctx, c, rpc.ErrorCode_UNAUTHENTICATED, err.Error())
+			c.Abort()
+			return
+		}
+		c.Set(principalContextKey, principal)
+		c.Next(context.WithValue(ctx, principalCtxKeyType{}, principal))
+	}
+}
+
+// GetPrincipal returns the authenticated caller, or nil if authentication is disabled.
+func GetPrincipal(c *app.RequestContext) *Principal {
+	if value, ok := c.Get(principalContextKey); ok {
+		return value.(*Principal)
+	}
+	return nil
+}
+
+func principalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalCtxKeyType{}).(*Principal)
+	return principal
+}
+
+// IsChatMember reports whether id is one of the members of a "<member1>:<member2>" chat.
+func IsChatMember(chat string, id string) bool {
+	for _, member := range strings.Split(chat, ":") {
+		if member == id {
+			return true
+		}
+	}
+	return false
+}
+
+// NewAuthenticatorFromConfig constructs the configured authenticators, returning
+// nil if none are configured, in which case authentication is disabled.
+func NewAuthenticatorFromConfig(cfg AuthConfig) Authenticator {
+	var chain ChainAuthenticator
+
+	if cfg.JWTKeysFile != "" {
+		keys, err := LoadJWKS(cfg.JWTKeysFile)
+		if err != nil {
+			log.Fatalf("Error loading JWT key set: %+v\n", err)
+		}
+		chain = append(chain, NewJWTAuthenticator(keys,
+			cfg.JWTIssuer,
+			cfg.JWTAudience,
+			cfg.JWTIdentityClaim,
+		))
+	}
+
+	if cfg.APIKeysFile != "" {
+		keys, err := LoadAPIKeys(cfg.APIKeysFile)
+		if err != nil {
+			log.Fatalf("Error loading API keys: %+v\n", err)
+		}
+		chain = append(chain, NewAPIKeyAuthenticator(keys))
+	}
+
+	if len(chain) == 0 {
+		return nil
+	}
+	return chain
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// LoadJWKS reads a JSON Web Key Set file containing RSA, EC or symmetric keys.
+func LoadJWKS(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]interface{}, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		key, err := jwk.verificationKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+func (jwk *jsonWebKey) verificationKey() (interface{}, error) {
+	decode := base64.RawURLEncoding.DecodeString
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decode(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := decode(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "oct":
+		return decode(jwk.K)
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+	}
+}
+
+// LoadAPIKeys reads a file of "<identity> <api key>" lines. Empty lines and
+// lines starting with '#' are ignored.
+func LoadAPIKeys(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	keys := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected \"<identity> <api key>\"", lineNo)
+		}
+		keys[fields[1]] = fields[0]
+	}
+	return keys, scanner.Err()
+}
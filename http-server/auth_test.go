@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/kitex_gen/rpc"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRSAKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Error when generating RSA key: %+v\n", err)
+	}
+	return key
+}
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("Error when signing test token: %+v\n", err)
+	}
+	return signed
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	key, otherKey := newTestRSAKey(t), newTestRSAKey(t)
+	authenticator := NewJWTAuthenticator(map[string]interface{}{"k1": &key.PublicKey}, "issuer", "imservice", "")
+	exp := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name   string
+		header string
+		id     string
+		err    error
+	}{
+		{
+			name:   "valid",
+			header: "Bearer " + signTestToken(t, jwt.SigningMethodRS256, "k1", key, jwt.MapClaims{"sub": "a", "iss": "issuer", "aud": "imservice", "exp": exp}),
+			id:     "a",
+		},
+		{
+			name:   "expired",
+			header: "Bearer " + signTestToken(t, jwt.SigningMethodRS256, "k1", key, jwt.MapClaims{"sub": "a", "iss": "issuer", "aud": "imservice", "exp": time.Now().Add(-time.Minute).Unix()}),
+			err:    errInvalidCredentials,
+		},
+		{
+			name:   "no exp",
+			header: "Bearer " + signTestToken(t, jwt.SigningMethodRS256, "k1", key, jwt.MapClaims{"sub": "a", "iss": "issuer", "aud": "imservice"}),
+			err:    errInvalidCredentials,
+		},
+		{
+			name:   "wrong key",
+			header: "Bearer " + signTestToken(t, jwt.SigningMethodRS256, "k1", otherKey, jwt.MapClaims{"sub": "a", "iss": "issuer", "aud": "imservice", "exp": exp}),
+			err:    errInvalidCredentials,
+		},
+		{
+			name:   "unknown key id",
+			header: "Bearer " + signTestToken(t, jwt.SigningMethodRS256, "k2", key, jwt.MapClaims{"sub": "a", "iss": "issuer", "aud": "imservice", "exp": exp}),
+			err:    errInvalidCredentials,
+		},
+		{
+			name:   "HMAC for an RSA key",
+			header: "Bearer " + signTestToken(t, jwt.SigningMethodHS256, "k1", key.PublicKey.N.Bytes(), jwt.MapClaims{"sub": "a", "iss": "issuer", "aud": "imservice", "exp": exp}),
+			err:    errInvalidCredentials,
+		},
+		{
+			name:   "wrong audience",
+			header: "Bearer " + signTestToken(t, jwt.SigningMethodRS256, "k1", key, jwt.MapClaims{"sub": "a", "iss": "issuer", "aud": "other", "exp": exp}),
+			err:    errInvalidCredentials,
+		},
+		{
+			name:   "wrong issuer",
+			header: "Bearer " + signTestToken(t, jwt.SigningMethodRS256, "k1", key, jwt.MapClaims{"sub": "a", "iss": "other", "aud": "imservice", "exp": exp}),
+			err:    errInvalidCredentials,
+		},
+		{
+			name:   "no identity",
+			header: "Bearer " + signTestToken(t, jwt.SigningMethodRS256, "k1", key, jwt.MapClaims{"iss": "issuer", "aud": "imservice", "exp": exp}),
+			err:    errInvalidCredentials,
+		},
+		{
+			name:   "malformed",
+			header: "Bearer not-a-token",
+			err:    errInvalidCredentials,
+		},
+		{
+			name:   "not a bearer token",
+			header: "Basic YTpi",
+			err:    errNoCredentials,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := app.NewContext(0)
+			c.Request.Header.Set("Authorization", test.header)
+			principal, err := authenticator.Authenticate(context.Background(), c)
+			if test.err != nil {
+				assert.True(t, errors.Is(err, test.err), "expected %v, got %v", test.err, err)
+				assert.Nil(t, principal)
+			} else if assert.Nil(t, err) {
+				assert.Equal(t, &Principal{ID: test.id, Method: "jwt"}, principal)
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	authenticator := NewAPIKeyAuthenticator(map[string]string{"secret": "service-a"})
+
+	tests := []struct {
+		name      string
+		key       string
+		principal *Principal
+		err       error
+	}{
+		{name: "match", key: "secret", principal: &Principal{ID: "service-a", Method: "apikey"}},
+		{name: "mismatch", key: "secret2", err: errInvalidCredentials},
+		{name: "none", err: errNoCredentials},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := app.NewContext(0)
+			if test.key != "" {
+				c.Request.Header.Set("X-API-Key", test.key)
+			}
+			principal, err := authenticator.Authenticate(context.Background(), c)
+			assert.Equal(t, test.principal, principal)
+			assert.True(t, errors.Is(err, test.err), "expected %v, got %v", test.err, err)
+		})
+	}
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	authenticator := ChainAuthenticator{NewAPIKeyAuthenticator(map[string]string{"secret": "a"})}
+	engine := route.NewEngine(config.NewOptions(nil))
+	api := engine.Group("/api", AuthMiddleware(authenticator))
+	registerAPIRoutes(api)
+	api.GET("/whoami", func(ctx context.Context, c *app.RequestContext) {
+		c.String(consts.StatusOK, GetPrincipal(c).ID+" "+principalFromContext(ctx).ID)
+	})
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		key    string
+		status int
+		code   rpc.ErrorCode
+	}{
+		{name: "no credentials", method: "GET", path: "/api/whoami", status: consts.StatusUnauthorized, code: rpc.ErrorCode_UNAUTHENTICATED},
+		{name: "invalid credentials", method: "GET", path: "/api/whoami", key: "wrong", status: consts.StatusUnauthorized, code: rpc.ErrorCode_UNAUTHENTICATED},
+		{name: "authenticated", method: "GET", path: "/api/whoami", key: "secret", status: consts.StatusOK},
+		{name: "sender mismatch", method: "POST", path: "/api/send", body: `{"chat": "a:b", "text": "hi", "sender": "b"}`, key: "secret", status: consts.StatusForbidden, code: rpc.ErrorCode_PERMISSION_DENIED},
+		{name: "not a chat member", method: "GET", path: "/api/pull", body: `{"chat": "b:c"}`, key: "secret", status: consts.StatusForbidden, code: rpc.ErrorCode_PERMISSION_DENIED},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			headers := []ut.Header{{Key: "Content-Type", Value: "application/json"}}
+			if test.key != "" {
+				headers = append(headers, ut.Header{Key: "X-API-Key", Value: test.key})
+			}
+			var reqBody *ut.Body
+			if test.body != "" {
+				reqBody = &ut.Body{Body: strings.NewReader(test.body), Len: -1}
+			}
+			resp := ut.PerformRequest(engine, test.method, test.path, reqBody, headers...).Result()
+			assert.Equal(t, test.status, resp.StatusCode())
+			if test.status == consts.StatusOK {
+				assert.Equal(t, "a a", string(resp.Body()))
+				return
+			}
+
+			var errBody struct {
+				Code int32 `json:"code"`
+			}
+			assert.Nil(t, json.Unmarshal(resp.Body(), &errBody))
+			assert.Equal(t, int32(test.code), errBody.Code)
+			if test.status == consts.StatusUnauthorized {
+				assert.Equal(t, `Bearer realm="imservice"`, resp.Header.Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestLoadKeys(t *testing.T) {
+	dir := t.TempDir()
+	jwksPath := filepath.Join(dir, "jwks.json")
+	secret := []byte("hmac secret")
+	jwks := `{"keys": [{"kty": "oct", "kid": "k1", "k": "` + base64.RawURLEncoding.EncodeToString(secret) + `"}]}`
+	if err := os.WriteFile(jwksPath, []byte(jwks), 0o600); err != nil {
+		t.Fatalf("Error when writing test key set: %+v\n", err)
+	}
+	keys, err := LoadJWKS(jwksPath)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"k1": secret}, keys)
+
+	apiKeysPath := filepath.Join(dir, "api_keys")
+	if err := os.WriteFile(apiKeysPath, []byte("# comment\nservice-a secret\n\n"), 0o600); err != nil {
+		t.Fatalf("Error when writing test API keys: %+v\n", err)
+	}
+	apiKeys, err := LoadAPIKeys(apiKeysPath)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"secret": "service-a"}, apiKeys)
+
+	if err := os.WriteFile(apiKeysPath, []byte("service-a\n"), 0o600); err != nil {
+		t.Fatalf("Error when writing test API keys: %+v\n", err)
+	}
+	_, err = LoadAPIKeys(apiKeysPath)
+	assert.EqualError(t, err, `line 1: expected "<identity> <api key>"`)
+}
+
+func TestMultiPull_ChatMembership(t *testing.T) {
+	stub := &stubIMClient{messages: []*rpc.Message{
+		{Chat: "a:b", Text: "hi", Sender: "b"},
+		{Chat: "b:c", Text: "secret", Sender: "c"},
+	}}
+	useTestClient(t, stub)
+
+	engine := route.NewEngine(config.NewOptions(nil))
+	registerAPIRoutes(engine.Group("/api", AuthMiddleware(ChainAuthenticator{NewAPIKeyAuthenticator(map[string]string{"secret": "a"})})))
+	resp := ut.PerformRequest(engine, "GET", "/api/pull/multi", &ut.Body{Body: strings.NewReader(`{"chats": ["a:b", "b:c", "c:"]}`), Len: -1},
+		ut.Header{Key: "Content-Type", Value: "application/json"}, ut.Header{Key: "X-API-Key", Value: "secret"}).Result()
+
+	// One chat the caller is not a member of only fails that chat's page
+	assert.Equal(t, consts.StatusOK, resp.StatusCode())
+	var body MultiPullResponseRest
+	assert.Nil(t, json.Unmarshal(resp.Body(), &body))
+	if assert.Equal(t, 3, len(body.Chats)) {
+		assert.Nil(t, body.Chats[0].Error)
+		assert.Equal(t, 1, len(body.Chats[0].Messages))
+
+		assert.Equal(t, "b:c", body.Chats[1].Chat)
+		assert.Empty(t, body.Chats[1].Messages)
+		if assert.NotNil(t, body.Chats[1].Error) {
+			assert.Equal(t, int32(rpc.ErrorCode_PERMISSION_DENIED), body.Chats[1].Error.Code)
+		}
+
+		if assert.NotNil(t, body.Chats[2].Error) {
+			assert.Equal(t, int32(rpc.ErrorCode_INVALID_ARGUMENT), body.Chats[2].Error.Code)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+// Command imctl is a command line client of the HTTP API, for sending, pulling
+// and exporting messages and listing chats.
+//
+// Usage:
+//
+//	imctl send [flags] <chat> <text>
+//	imctl pull [flags] <chat>
+//	imctl chats [flags]
+//	imctl export [flags] <chat>
+//
+// The server and credentials are given by the --url, --token and --api-key
+// flags, or the IMCTL_URL, IMCTL_TOKEN and IMCTL_API_KEY environment variables.
+// Output is a human readable transcript, or with -o json, one JSON object per
+// line.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/imclient"
+)
+
+const usage = `usage: imctl <command> [flags] [arguments]
+
+commands:
+  send <chat> <text>  send a message
+  pull <chat>         pull a page of messages, or with --follow every message and then new ones
+  chats               list the chats of the authenticated user, most recently active first
+  export <chat>       print every message of a chat
+
+Run imctl <command> -h for the flags of a command.
+`
+
+type command struct {
+	name string
+	args string
+	// setup registers the flags of the command and returns the function running
+	// it with the arguments left after parsing them.
+	setup func(fs *flag.FlagSet) func(ctx context.Context, client *imclient.Client, out *output, args []string) error
+}
+
+var commands = []command{
+	{"send", "<chat> <text>", sendCommand},
+	{"pull", "<chat>", pullCommand},
+	{"chats", "", chatsCommand},
+	{"export", "<chat>", exportCommand},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err := run(ctx, os.Args[1], os.Args[2:], os.Stdout)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "imctl: %v\n", err)
+		if errors.Is(err, errUsage) {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+var errUsage = errors.New("invalid usage")
+
+func run(ctx context.Context, name string, args []string, stdout io.Writer) error {
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+
+		fs := flag.NewFlagSet("imctl "+cmd.name, flag.ContinueOnError)
+		fs.Usage = func() {
+			fmt.Fprintf(fs.Output(), "usage: imctl %s [flags] %s\n\nflags:\n", cmd.name, cmd.args)
+			fs.PrintDefaults()
+		}
+		global := registerGlobalFlags(fs)
+		runCommand := cmd.setup(fs)
+
+		positional, err := parseInterspersed(fs, args)
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		} else if err != nil {
+			return fmt.Errorf("%w: %v", errUsage, err)
+		}
+		out, err := newOutput(global.output, stdout)
+		if err != nil {
+			return err
+		}
+
+		opts := []imclient.Option{imclient.WithHTTPClient(&http.Client{Timeout: global.timeout})}
+		if global.token != "" {
+			opts = append(opts, imclient.WithToken(global.token))
+		}
+		if global.apiKey != "" {
+			opts = append(opts, imclient.WithAPIKey(global.apiKey))
+		}
+		return runCommand(ctx, imclient.New(global.url, opts...), out, positional)
+	}
+
+	if name == "-h" || name == "--help" || name == "help" {
+		fmt.Fprint(stdout, usage)
+		return nil
+	}
+	return fmt.Errorf("%w: unknown command %q, run imctl -h for the list of commands", errUsage, name)
+}
+
+type globalFlags struct {
+	url     string
+	token   string
+	apiKey  string
+	output  string
+	timeout time.Duration
+}
+
+// registerGlobalFlags registers the flags shared by every command, which
+// default to their environment variables.
+func registerGlobalFlags(fs *flag.FlagSet) *globalFlags {
+	global := new(globalFlags)
+	fs.StringVar(&global.url, "url", envOr("IMCTL_URL", "http://localhost:8080"), "URL of the http-server (IMCTL_URL)")
+	fs.StringVar(&global.token, "token", os.Getenv("IMCTL_TOKEN"), "JWT to authenticate with (IMCTL_TOKEN)")
+	fs.StringVar(&global.apiKey, "api-key", os.Getenv("IMCTL_API_KEY"), "API key to authenticate with (IMCTL_API_KEY)")
+	fs.StringVar(&global.output, "o", envOr("IMCTL_OUTPUT", "text"), "output format, text or json (IMCTL_OUTPUT)")
+	fs.DurationVar(&global.timeout, "timeout", 10*time.Second, "timeout of each request")
+	return global
+}
+
+func envOr(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
+
+// parseInterspersed parses the flags of fs wherever they are among args,
+// returning the remaining positional arguments in order.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+func sendCommand(fs *flag.FlagSet) func(context.Context, *imclient.Client, *output, []string) error {
+	sender := fs.String("sender", "", "sender of the message, defaults to the authenticated caller")
+	return func(ctx context.Context, client *imclient.Client, out *output, args []string) error {
+		if len(args) < 2 {
+			return fmt.Errorf("%w: send needs a chat and the text of the message", errUsage)
+		}
+		return client.Send(ctx, &imclient.SendRequest{
+			Chat:   args[0],
+			Text:   strings.Join(args[1:], " "),
+			Sender: *sender,
+		})
+	}
+}
+
+func pullCommand(fs *flag.FlagSet) func(context.Context, *imclient.Client, *output, []string) error {
+	limit := fs.Int("limit", 10, "maximum number of messages to pull per request")
+	cursor := fs.Int64("cursor", 0, "position to start pulling from")
+	reverse := fs.Bool("reverse", false, "pull the latest messages first")
+	follow := fs.Bool("follow", false, "pull every message, then wait for new ones until interrupted")
+	interval := fs.Duration("interval", time.Second, "interval between pulls for new messages with --follow")
+	return func(ctx context.Context, client *imclient.Client, out *output, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("%w: pull needs a chat", errUsage)
+		}
+		req := imclient.PullRequest{Chat: args[0], Cursor: *cursor, Limit: int32(*limit), Reverse: *reverse}
+
+		if !*follow {
+			resp, err := client.Pull(ctx, &req)
+			if err != nil {
+				return err
+			}
+			for _, message := range resp.Messages {
+				if err := out.message(message); err != nil {
+					return err
+				}
+			}
+			if resp.HasMore {
+				fmt.Fprintf(os.Stderr, "more messages, continue with --cursor %d\n", resp.NextCursor)
+			}
+			return nil
+		}
+
+		if *reverse {
+			return fmt.Errorf("%w: --follow cannot be used with --reverse", errUsage)
+		}
+		for {
+			messages := client.Messages(ctx, req)
+			for messages.Next() {
+				if err := out.message(messages.Message()); err != nil {
+					return err
+				}
+			}
+			if ctx.Err() != nil {
+				return nil
+			} else if err := messages.Err(); err != nil {
+				return err
+			}
+
+			req.Cursor = messages.Cursor()
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(*interval):
+			}
+		}
+	}
+}
+
+func chatsCommand(fs *flag.FlagSet) func(context.Context, *imclient.Client, *output, []string) error {
+	user := fs.String("user", "", "user to list the chats of, defaults to the authenticated caller")
+	return func(ctx context.Context, client *imclient.Client, out *output, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("%w: chats takes no arguments", errUsage)
+		}
+
+		req := &imclient.ListChatsRequest{User: *user}
+		for {
+			resp, err := client.ListChats(ctx, req)
+			if err != nil {
+				return err
+			}
+			for _, chat := range resp.Chats {
+				if err := out.chat(chat); err != nil {
+					return err
+				}
+			}
+			if !resp.HasMore {
+				return out.flush()
+			}
+			req.Cursor = resp.NextCursor
+		}
+	}
+}
+
+func exportCommand(fs *flag.FlagSet) func(context.Context, *imclient.Client, *output, []string) error {
+	reverse := fs.Bool("reverse", false, "export the latest messages first")
+	file := fs.String("out", "", "file to write to instead of standard output")
+	return func(ctx context.Context, client *imclient.Client, out *output, args []string) (err error) {
+		if len(args) != 1 {
+			return fmt.Errorf("%w: export needs a chat", errUsage)
+		}
+
+		if *file != "" {
+			var f *os.File
+			if f, err = os.Create(*file); err != nil {
+				return err
+			}
+			// Closing can fail to write the end of the export
+			defer func() {
+				if closeErr := f.Close(); err == nil {
+					err = closeErr
+				}
+			}()
+			out.w = f
+		}
+
+		// Exports are paged by the position of their messages rather than an
+		// offset, so messages sent meanwhile are neither repeated nor skipped
+		messages := client.ExportMessages(ctx, imclient.ExportRequest{Chat: args[0], Reverse: *reverse})
+		for messages.Next() {
+			if err := out.message(messages.Message()); err != nil {
+				return err
+			}
+		}
+		return messages.Err()
+	}
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/imclient"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubServer is an in-memory HTTP API, storing the messages sent and
+// paginating them by offset as the http-server does, or for exports by send
+// time. Chats are listed one per page unless a limit is given, so that listing
+// them takes several requests.
+type stubServer struct {
+	mu       sync.Mutex
+	messages []*imclient.Message
+	pulls    int
+	listings int
+	// exported is called with s.mu held after each page of an export
+	exported func()
+}
+
+func (s *stubServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var resp interface{}
+	switch r.URL.Path {
+	case "/api/send":
+		var req imclient.SendRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Sender == "" {
+			req.Sender = "a"
+		}
+		s.add(req.Chat, req.Text, req.Sender)
+		resp = struct{}{}
+	case "/api/pull":
+		var req imclient.PullRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.pulls++
+		if req.Limit == 0 {
+			req.Limit = 10
+		}
+		var messages []*imclient.Message
+		for _, message := range s.messages {
+			if message.Chat == req.Chat {
+				messages = append(messages, message)
+			}
+		}
+		pullResp := &imclient.PullResponse{Messages: []*imclient.Message{}}
+		if int(req.Cursor) < len(messages) {
+			messages = messages[req.Cursor:]
+			if len(messages) > int(req.Limit) {
+				messages = messages[:req.Limit]
+				pullResp.HasMore = true
+			}
+			pullResp.Messages = messages
+		}
+		pullResp.NextCursor = req.Cursor + int64(len(pullResp.Messages))
+		resp = pullResp
+	case "/api/chats":
+		var req imclient.ListChatsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.listings++
+		if req.Limit == 0 {
+			req.Limit = 1
+		}
+		var chats []*imclient.ChatSummary
+		seen := make(map[string]*imclient.ChatSummary)
+		for i := len(s.messages) - 1; i >= 0; i-- {
+			message := s.messages[i]
+			if chat, ok := seen[message.Chat]; ok {
+				chat.MessageCount++
+				continue
+			}
+			seen[message.Chat] = &imclient.ChatSummary{Chat: message.Chat, LastSendTime: message.SendTime, MessageCount: 1}
+			chats = append(chats, seen[message.Chat])
+		}
+		listResp := &imclient.ListChatsResponse{Chats: []*imclient.ChatSummary{}}
+		if int(req.Cursor) < len(chats) {
+			chats = chats[req.Cursor:]
+			if len(chats) > int(req.Limit) {
+				chats = chats[:req.Limit]
+				listResp.HasMore = true
+			}
+			listResp.Chats = chats
+		}
+		listResp.NextCursor = req.Cursor + int64(len(listResp.Chats))
+		resp = listResp
+	case "/api/export":
+		var req imclient.ExportRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Limit == 0 {
+			req.Limit = 100
+		}
+		var after int64
+		if req.Cursor != "" {
+			after, _ = strconv.ParseInt(req.Cursor, 10, 64)
+		}
+		var messages []*imclient.Message
+		for _, message := range s.messages {
+			if message.Chat != req.Chat {
+				continue
+			}
+			if req.Cursor == "" || (!req.Reverse && message.SendTime > after) || (req.Reverse && message.SendTime < after) {
+				messages = append(messages, message)
+			}
+		}
+		if req.Reverse {
+			sort.Slice(messages, func(i, j int) bool { return messages[i].SendTime > messages[j].SendTime })
+		}
+		exportResp := &imclient.ExportResponse{Messages: messages}
+		if len(messages) > int(req.Limit) {
+			exportResp.Messages = messages[:req.Limit]
+			exportResp.HasMore = true
+			exportResp.NextCursor = strconv.FormatInt(exportResp.Messages[req.Limit-1].SendTime, 10)
+		}
+		resp = exportResp
+		if s.exported != nil {
+			s.exported()
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		resp = &imclient.Error{Code: imclient.CodeNotFound, Message: "not found"}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *stubServer) send(chat, text, sender string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.add(chat, text, sender)
+}
+
+// add stores a message sent a second after the previous one, with s.mu held.
+func (s *stubServer) add(chat, text, sender string) {
+	s.messages = append(s.messages, &imclient.Message{Chat: chat, Text: text, Sender: sender, SendTime: int64(len(s.messages)+1) * 1e6})
+}
+
+// startStubServer serves stub until the test ends, returning its URL.
+func startStubServer(t *testing.T, stub *stubServer) string {
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// transcriptLine returns the line of the text output of a message.
+func transcriptLine(message *imclient.Message) string {
+	return "[" + formatTime(message.SendTime) + "] " + message.Sender + ": " + message.Text + "\n"
+}
+
+func TestRun_Send(t *testing.T) {
+	stub := &stubServer{}
+	url := startStubServer(t, stub)
+	ctx := context.Background()
+
+	var stdout bytes.Buffer
+	assert.Nil(t, run(ctx, "send", []string{"--url", url, "a:b", "hello", "there"}, &stdout))
+	assert.Nil(t, run(ctx, "send", []string{"a:b", "--sender", "b", "hi", "--url", url}, &stdout))
+	assert.Empty(t, stdout.String())
+
+	assert.Len(t, stub.messages, 2)
+	assert.Equal(t, "hello there", stub.messages[0].Text)
+	assert.Equal(t, "a", stub.messages[0].Sender)
+	assert.Equal(t, "hi", stub.messages[1].Text)
+	assert.Equal(t, "b", stub.messages[1].Sender)
+
+	err := run(ctx, "send", []string{"--url", url, "a:b"}, &stdout)
+	assert.True(t, errors.Is(err, errUsage))
+	assert.Len(t, stub.messages, 2)
+}
+
+func TestRun_Pull(t *testing.T) {
+	stub := &stubServer{}
+	for _, text := range []string{"0", "1", "2"} {
+		stub.send("a:b", text, "a")
+	}
+	url := startStubServer(t, stub)
+
+	var stdout bytes.Buffer
+	assert.Nil(t, run(context.Background(), "pull", []string{"--url", url, "--limit", "2", "--cursor", "1", "a:b"}, &stdout))
+	assert.Equal(t, transcriptLine(stub.messages[1])+transcriptLine(stub.messages[2]), stdout.String())
+
+	stdout.Reset()
+	err := run(context.Background(), "pull", []string{"--url", url, "--follow", "--reverse", "a:b"}, &stdout)
+	assert.True(t, errors.Is(err, errUsage))
+}
+
+func TestRun_PullFollow(t *testing.T) {
+	stub := &stubServer{}
+	for _, text := range []string{"0", "1", "2"} {
+		stub.send("a:b", text, "a")
+	}
+	url := startStubServer(t, stub)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pulls := func() int {
+		stub.mu.Lock()
+		defer stub.mu.Unlock()
+		return stub.pulls
+	}
+
+	// A message sent while following is printed after the existing ones, and
+	// the command returns without an error once cancelled
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		stub.send("a:b", "3", "b")
+		// The pull after the one in flight has the new message
+		for sentAt := pulls(); pulls() < sentAt+2; {
+			time.Sleep(10 * time.Millisecond)
+		}
+		cancel()
+	}()
+
+	var stdout bytes.Buffer
+	err := run(ctx, "pull", []string{"--url", url, "--follow", "--limit", "2", "--interval", "20ms", "a:b"}, &stdout)
+	assert.Nil(t, err)
+
+	var want string
+	for _, message := range stub.messages {
+		want += transcriptLine(message)
+	}
+	assert.Equal(t, want, stdout.String())
+}
+
+func TestRun_Chats(t *testing.T) {
+	stub := &stubServer{}
+	stub.send("a:b", "0", "a")
+	stub.send("a:c", "1", "a")
+	stub.send("a:b", "2", "b")
+	stub.send("a:d", "3", "d")
+	url := startStubServer(t, stub)
+
+	// Every page is listed, most recently active first
+	var stdout bytes.Buffer
+	assert.Nil(t, run(context.Background(), "chats", []string{"--url", url}, &stdout))
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	assert.Len(t, lines, 4)
+	assert.Equal(t, []string{"CHAT", "MESSAGES", "LAST", "MESSAGE"}, strings.Fields(lines[0]))
+	for i, chat := range []string{"a:d", "a:b", "a:c"} {
+		assert.True(t, strings.HasPrefix(lines[i+1], chat+" "), lines[i+1])
+	}
+	assert.Equal(t, 3, stub.listings)
+}
+
+func TestRun_JSONOutput(t *testing.T) {
+	stub := &stubServer{}
+	stub.send("a:b", "0", "a")
+	stub.send("a:c", "1", "a")
+	stub.send("a:b", "2", "b")
+	url := startStubServer(t, stub)
+
+	var stdout bytes.Buffer
+	assert.Nil(t, run(context.Background(), "chats", []string{"--url", url, "-o", "json"}, &stdout))
+	decoder := json.NewDecoder(&stdout)
+	var chats []imclient.ChatSummary
+	for decoder.More() {
+		var chat imclient.ChatSummary
+		assert.Nil(t, decoder.Decode(&chat))
+		chats = append(chats, chat)
+	}
+	assert.Equal(t, []imclient.ChatSummary{
+		{Chat: "a:b", LastSendTime: 3e6, MessageCount: 2},
+		{Chat: "a:c", LastSendTime: 2e6, MessageCount: 1},
+	}, chats)
+
+	stdout.Reset()
+	assert.Nil(t, run(context.Background(), "pull", []string{"--url", url, "-o", "json", "a:b"}, &stdout))
+	decoder = json.NewDecoder(&stdout)
+	var messages []*imclient.Message
+	for decoder.More() {
+		var message imclient.Message
+		assert.Nil(t, decoder.Decode(&message))
+		messages = append(messages, &message)
+	}
+	assert.Equal(t, []*imclient.Message{stub.messages[0], stub.messages[2]}, messages)
+
+	err := run(context.Background(), "chats", []string{"--url", url, "-o", "yaml"}, &stdout)
+	assert.True(t, errors.Is(err, errUsage))
+}
+
+func TestRun_ExportToFile(t *testing.T) {
+	stub := &stubServer{}
+	var want string
+	for i := 0; i < 150; i++ {
+		stub.send("a:b", strings.Repeat("x", i), "a")
+		want += transcriptLine(stub.messages[i])
+	}
+	url := startStubServer(t, stub)
+	file := filepath.Join(t.TempDir(), "export.txt")
+
+	var stdout bytes.Buffer
+	assert.Nil(t, run(context.Background(), "export", []string{"--url", url, "--out", file, "a:b"}, &stdout))
+	assert.Empty(t, stdout.String())
+	exported, err := os.ReadFile(file)
+	assert.Nil(t, err)
+	assert.Equal(t, want, string(exported))
+}
+
+func TestRun_ExportReverse(t *testing.T) {
+	stub := &stubServer{}
+	for i := 0; i < 250; i++ {
+		stub.send("a:b", strconv.Itoa(i), "a")
+	}
+	// Messages sent during the export would move the following pages, were
+	// they pulled by offset
+	stub.exported = func() {
+		stub.add("a:b", "new", "b")
+	}
+	want := make([]string, 0, 250)
+	for i := 249; i >= 0; i-- {
+		want = append(want, transcriptLine(stub.messages[i]))
+	}
+	url := startStubServer(t, stub)
+
+	var stdout bytes.Buffer
+	assert.Nil(t, run(context.Background(), "export", []string{"--url", url, "--reverse", "a:b"}, &stdout))
+	assert.Equal(t, strings.Join(want, ""), stdout.String())
+	assert.Len(t, stub.messages, 253)
+}
+
+func TestRun_Errors(t *testing.T) {
+	var stdout bytes.Buffer
+	err := run(context.Background(), "unknown", nil, &stdout)
+	assert.True(t, errors.Is(err, errUsage))
+
+	err = run(context.Background(), "pull", []string{"--unknown"}, &stdout)
+	assert.True(t, errors.Is(err, errUsage))
+
+	assert.Nil(t, run(context.Background(), "help", nil, &stdout))
+	assert.Equal(t, usage, stdout.String())
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/imclient"
+)
+
+const timeFormat = "2006-01-02 15:04:05"
+
+// output writes messages and chats as a human readable transcript or table, or
+// as one JSON object per line.
+type output struct {
+	w     io.Writer
+	json  bool
+	table *tabwriter.Writer
+}
+
+func newOutput(format string, w io.Writer) (*output, error) {
+	switch format {
+	case "text":
+		return &output{w: w}, nil
+	case "json":
+		return &output{w: w, json: true}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown output format %q, must be text or json", errUsage, format)
+}
+
+func (o *output) message(message *imclient.Message) error {
+	if o.json {
+		return json.NewEncoder(o.w).Encode(message)
+	}
+
+	text := message.Text
+	if message.Encrypted {
+		text = fmt.Sprintf("<encrypted with key %s>", message.SenderKeyID)
+	}
+	_, err := fmt.Fprintf(o.w, "[%s] %s: %s\n", formatTime(message.SendTime), message.Sender, text)
+	return err
+}
+
+// chat writes a chat, as a row of a table flushed by flush in text output.
+func (o *output) chat(chat *imclient.ChatSummary) error {
+	if o.json {
+		return json.NewEncoder(o.w).Encode(chat)
+	}
+
+	if o.table == nil {
+		o.table = tabwriter.NewWriter(o.w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(o.table, "CHAT\tMESSAGES\tLAST MESSAGE")
+	}
+	_, err := fmt.Fprintf(o.table, "%s\t%d\t%s\n", chat.Chat, chat.MessageCount, formatTime(chat.LastSendTime))
+	return err
+}
+
+func (o *output) flush() error {
+	if o.table == nil {
+		return nil
+	}
+	return o.table.Flush()
+}
+
+// formatTime formats a time in microseconds in the local time zone.
+func formatTime(micros int64) string {
+	return time.UnixMicro(micros).Local().Format(timeFormat)
+}
@@ -0,0 +1,193 @@
+// Command openapi-gen generates the OpenAPI document of the HTTP API from the
+// MessageService definition in idl_http.proto, whose methods are commented with
+// the HTTP method and path they are served at, e.g. "// POST /api/send".
+//
+// Usage: openapi-gen -proto ../idl_http.proto -out openapi.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	serviceName         = "MessageService"
+	protobufContentType = "application/x-protobuf"
+)
+
+var routePattern = regexp.MustCompile(`^(GET|POST|PUT|DELETE) (/\S*)(?:,\s*(.*))?$`)
+
+func main() {
+	protoPath := flag.String("proto", "../idl_http.proto", "path of idl_http.proto")
+	out := flag.String("out", "openapi.json", "path to write the OpenAPI document to")
+	flag.Parse()
+
+	document, err := generate(*protoPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	encoded, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, append(encoded, '\n'), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// object is a JSON object of the OpenAPI document. Its keys are written in
+// sorted order, keeping the document stable across runs.
+type object = map[string]interface{}
+
+func generate(protoPath string) (object, error) {
+	parser := protoparse.Parser{
+		ImportPaths:           []string{filepath.Dir(protoPath)},
+		IncludeSourceCodeInfo: true,
+	}
+	files, err := parser.ParseFiles(filepath.Base(protoPath))
+	if err != nil {
+		return nil, err
+	}
+	file := files[0]
+
+	service := file.FindService(file.GetPackage() + "." + serviceName)
+	if service == nil {
+		return nil, fmt.Errorf("%s: service %s not found", protoPath, serviceName)
+	}
+
+	paths := object{}
+	for _, method := range service.GetMethods() {
+		comment := strings.TrimSpace(method.GetSourceInfo().GetTrailingComments())
+		match := routePattern.FindStringSubmatch(comment)
+		if match == nil {
+			return nil, fmt.Errorf("%s: method %s is not commented with its HTTP method and path", protoPath, method.GetName())
+		}
+		httpMethod, path, notes := strings.ToLower(match[1]), match[2], match[3]
+
+		operation := object{
+			"operationId": method.GetName(),
+			"requestBody": object{
+				"required": true,
+				"content":  content(method.GetInputType()),
+			},
+			"responses": object{
+				"200": object{
+					"description": "OK",
+					"content":     content(method.GetOutputType()),
+				},
+				"default": object{
+					"description": "Error, with the HTTP status of its code",
+					"content":     content(file.FindMessage(file.GetPackage() + ".Error")),
+				},
+			},
+		}
+		if notes != "" {
+			operation["description"] = strings.ToUpper(notes[:1]) + notes[1:] + "."
+		}
+
+		if _, ok := paths[path]; !ok {
+			paths[path] = object{}
+		}
+		paths[path].(object)[httpMethod] = operation
+	}
+
+	schemas := object{}
+	for _, message := range file.GetMessageTypes() {
+		schemas[message.GetName()] = schema(message)
+	}
+
+	return object{
+		"openapi": "3.1.0",
+		"info": object{
+			"title":       "IM Service HTTP API",
+			"description": "Generated from idl_http.proto. Request and response bodies are JSON, or protobuf with the application/x-protobuf content type.",
+			"version":     "1.0.0",
+		},
+		"paths": paths,
+		"components": object{
+			"schemas": schemas,
+			"securitySchemes": object{
+				"bearerAuth": object{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+				"apiKeyAuth": object{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+			},
+		},
+		// Authentication is only enforced when the server is configured with keys
+		"security": []interface{}{object{"bearerAuth": []string{}}, object{"apiKeyAuth": []string{}}, object{}},
+	}, nil
+}
+
+// content describes a body encoded as message. Messages without fields are
+// sent without a JSON body.
+func content(message *desc.MessageDescriptor) object {
+	bodies := object{
+		protobufContentType: object{
+			"schema": object{"type": "string", "format": "binary", "description": "Encoded " + message.GetFullyQualifiedName()},
+		},
+	}
+	if len(message.GetFields()) > 0 {
+		bodies["application/json"] = object{"schema": reference(message)}
+	}
+	return bodies
+}
+
+func reference(message *desc.MessageDescriptor) object {
+	return object{"$ref": "#/components/schemas/" + message.GetName()}
+}
+
+func schema(message *desc.MessageDescriptor) object {
+	properties := object{}
+	for _, field := range message.GetFields() {
+		property := fieldSchema(field)
+		if field.IsRepeated() {
+			property = object{"type": "array", "items": property}
+		}
+		if description := comment(field.GetSourceInfo()); description != "" {
+			property["description"] = description
+		}
+		// JSON bodies use the field names of the proto definition
+		properties[field.GetName()] = property
+	}
+
+	result := object{"type": "object", "properties": properties}
+	if description := comment(message.GetSourceInfo()); description != "" {
+		result["description"] = description
+	}
+	return result
+}
+
+func fieldSchema(field *desc.FieldDescriptor) object {
+	switch field.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return object{"type": "string"}
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return object{"type": "boolean"}
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_TYPE_SINT32, descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		return object{"type": "integer", "format": "int32"}
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64, descriptorpb.FieldDescriptorProto_TYPE_SINT64, descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		return object{"type": "integer", "format": "int64"}
+	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
+		return reference(field.GetMessageType())
+	default:
+		log.Fatalf("field %s: unsupported type %s", field.GetFullyQualifiedName(), field.GetType())
+		return nil
+	}
+}
+
+// comment returns the comment documenting an element, preferring the comment
+// preceding it to the one trailing it.
+func comment(info *descriptorpb.SourceCodeInfo_Location) string {
+	if leading := strings.TrimSpace(info.GetLeadingComments()); leading != "" {
+		return strings.Join(strings.Fields(leading), " ")
+	}
+	return strings.TrimSpace(info.GetTrailingComments())
+}
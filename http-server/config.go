@@ -0,0 +1,197 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// Config is the configuration of the http-server, loaded by configload.Load. See
+// common/configload for the meaning of the struct tags.
+type Config struct {
+	Server    ServerConfig    `yaml:"server"`
+	Discovery DiscoveryConfig `yaml:"discovery"`
+	RPC       RPCConfig       `yaml:"rpc"`
+	Auth      AuthConfig      `yaml:"auth"`
+	S2S       S2SConfig       `yaml:"s2s"`
+	Logging   LoggingConfig   `yaml:"logging"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	Shutdown  ShutdownConfig  `yaml:"shutdown"`
+}
+
+type ServerConfig struct {
+	// Name the server identifies itself with to the rpc-server.
+	ServiceName string `yaml:"service_name" env:"SERVICE_NAME" flag:"service-name" usage:"name the server identifies itself with"`
+	Addr        string `yaml:"addr" env:"HTTP_ADDR" flag:"addr" usage:"address to serve HTTP on"`
+	// Address to serve the MessageService of idl_http.proto on over gRPC and
+	// gRPC-Web, which is not served if empty, as it is by default.
+	GRPCAddr string `yaml:"grpc_addr" env:"GRPC_ADDR" flag:"grpc-addr" usage:"address to serve gRPC and gRPC-Web on, empty to disable"`
+	// Origins, e.g. "https://app.example.com", whose pages may call the HTTP and
+	// gRPC-Web APIs from browsers. "*" allows every origin, and none are allowed
+	// by default.
+	CORSOrigins []string `yaml:"cors_origins" env:"CORS_ORIGINS" flag:"cors-origins" usage:"comma separated origins browsers may call the APIs from"`
+}
+
+type DiscoveryConfig struct {
+	// One of etcd, static or dns.
+	Type          string   `yaml:"type" env:"DISCOVERY_TYPE" flag:"discovery" usage:"service discovery (etcd, static or dns)"`
+	EtcdEndpoints []string `yaml:"etcd_endpoints" env:"ETCD_ENDPOINTS" flag:"etcd-endpoints" usage:"comma separated etcd endpoints"`
+	// Addresses of the rpc-server instances for static discovery.
+	StaticAddrs []string `yaml:"static_addrs" env:"DISCOVERY_STATIC_ADDRS" flag:"static-addrs" usage:"comma separated rpc-server addresses for static discovery"`
+	// SRV record, or host and port, looked up for DNS discovery.
+	DNSRecord string `yaml:"dns_record" env:"DISCOVERY_DNS_RECORD" flag:"dns-record" usage:"SRV record, or host:port, of the rpc-server for DNS discovery"`
+}
+
+type RPCConfig struct {
+	ServiceName string        `yaml:"service_name" env:"RPC_SERVICE_NAME" flag:"rpc-service-name" usage:"name the rpc-server is registered under"`
+	Timeout     time.Duration `yaml:"timeout" env:"RPC_TIMEOUT" flag:"rpc-timeout" usage:"timeout of each RPC"`
+	// Failed calls to RetryMethods are retried up to RetryMaxTimes times, zero
+	// disables retries. Only idempotent methods may be retried.
+	RetryMaxTimes int           `yaml:"retry_max_times" env:"RPC_RETRY_MAX_TIMES" flag:"rpc-retry-max-times" usage:"times failed idempotent RPCs are retried"`
+	RetryMethods  []string      `yaml:"retry_methods" env:"RPC_RETRY_METHODS"`
+	RetryBackoff  time.Duration `yaml:"retry_backoff" env:"RPC_RETRY_BACKOFF"`
+	// Calls to an instance are rejected once more than BreakerErrorRate of at
+	// least BreakerMinSample recent calls to it failed, zero disables the breaker.
+	BreakerErrorRate float64 `yaml:"breaker_error_rate" env:"RPC_BREAKER_ERROR_RATE"`
+	BreakerMinSample int     `yaml:"breaker_min_sample" env:"RPC_BREAKER_MIN_SAMPLE"`
+}
+
+type AuthConfig struct {
+	// Authentication is disabled if neither key file is set.
+	JWTKeysFile      string `yaml:"jwt_keys_file" env:"AUTH_JWT_KEYS_FILE"`
+	JWTIssuer        string `yaml:"jwt_issuer" env:"AUTH_JWT_ISSUER"`
+	JWTAudience      string `yaml:"jwt_audience" env:"AUTH_JWT_AUDIENCE"`
+	JWTIdentityClaim string `yaml:"jwt_identity_claim" env:"AUTH_JWT_IDENTITY_CLAIM"`
+	APIKeysFile      string `yaml:"api_keys_file" env:"AUTH_API_KEYS_FILE"`
+}
+
+type S2SConfig struct {
+	// RPC calls are not signed if empty.
+	SharedSecret string `yaml:"shared_secret" env:"S2S_SHARED_SECRET" secret:"true"`
+}
+
+type LoggingConfig struct {
+	// One of debug, info, warn or error.
+	Level string `yaml:"level" env:"LOG_LEVEL" flag:"log-level" usage:"minimum log level (debug, info, warn or error)"`
+	// One of json or text.
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+}
+
+type TracingConfig struct {
+	// One of none, otlp or stdout.
+	Exporter string `yaml:"exporter" env:"OTEL_TRACES_EXPORTER"`
+}
+
+// DefaultConfig returns the configuration used when nothing is overridden.
+func DefaultConfig() *Config {
+	return &Config{
+		Server: ServerConfig{
+			ServiceName: "http-server",
+			Addr:        "0.0.0.0:8080",
+		},
+		Discovery: DiscoveryConfig{
+			Type:          "etcd",
+			EtcdEndpoints: []string{"etcd:2379"},
+		},
+		RPC: RPCConfig{
+			ServiceName: "demo.rpc.server",
+			Timeout:     1 * time.Second,
+
+			RetryMaxTimes:    2,
+			RetryMethods:     []string{"Pull", "MultiPull", "ListChats", "ListBlocked", "GetKeys", "QueryAuditLog", "ListFlags"},
+			RetryBackoff:     10 * time.Millisecond,
+			BreakerErrorRate: 0.5,
+			BreakerMinSample: 200,
+		},
+		Logging: LoggingConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		Tracing: TracingConfig{
+			Exporter: "none",
+		},
+		Shutdown: ShutdownConfig{
+			Delay:   3 * time.Second,
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// Validate reports the first invalid setting in the configuration.
+func (c *Config) Validate() error {
+	if c.Server.ServiceName == "" {
+		return errors.New("server.service_name is required")
+	}
+	if _, _, err := net.SplitHostPort(c.Server.Addr); err != nil {
+		return fmt.Errorf("server.addr: %w", err)
+	}
+	if c.Server.GRPCAddr != "" {
+		if _, _, err := net.SplitHostPort(c.Server.GRPCAddr); err != nil {
+			return fmt.Errorf("server.grpc_addr: %w", err)
+		}
+	}
+	for _, origin := range c.Server.CORSOrigins {
+		if u, err := url.Parse(origin); origin != "*" && (err != nil || u.Scheme == "" || u.Host == "" || u.Path != "" || u.RawQuery != "") {
+			return fmt.Errorf("server.cors_origins: invalid origin %q", origin)
+		}
+	}
+	switch c.Discovery.Type {
+	case "etcd":
+		if len(c.Discovery.EtcdEndpoints) == 0 {
+			return errors.New("discovery.etcd_endpoints is required for etcd discovery")
+		}
+	case "static":
+		if len(c.Discovery.StaticAddrs) == 0 {
+			return errors.New("discovery.static_addrs is required for static discovery")
+		}
+		for _, addr := range c.Discovery.StaticAddrs {
+			if _, _, err := net.SplitHostPort(addr); err != nil {
+				return fmt.Errorf("discovery.static_addrs: %w", err)
+			}
+		}
+	case "dns":
+		if c.Discovery.DNSRecord == "" {
+			return errors.New("discovery.dns_record is required for DNS discovery")
+		}
+	default:
+		return fmt.Errorf("discovery.type: unknown discovery %s", c.Discovery.Type)
+	}
+	if c.RPC.ServiceName == "" {
+		return errors.New("rpc.service_name is required")
+	}
+	if c.RPC.Timeout <= 0 {
+		return errors.New("rpc.timeout must be positive")
+	}
+	if c.RPC.RetryMaxTimes < 0 || c.RPC.RetryBackoff < 0 {
+		return errors.New("rpc.retry_max_times and rpc.retry_backoff must not be negative")
+	}
+	for _, method := range c.RPC.RetryMethods {
+		if !isIdempotent(method) {
+			return fmt.Errorf("rpc.retry_methods: %s is not idempotent and cannot be retried", method)
+		}
+	}
+	if c.RPC.BreakerErrorRate < 0 || c.RPC.BreakerErrorRate > 1 || c.RPC.BreakerMinSample < 1 {
+		return errors.New("rpc.breaker_error_rate must be between 0 and 1 and rpc.breaker_min_sample positive")
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Logging.Level)); err != nil {
+		return fmt.Errorf("logging.level: %w", err)
+	}
+	if format := strings.ToLower(c.Logging.Format); format != "json" && format != "text" {
+		return fmt.Errorf("logging.format: unknown format %s", c.Logging.Format)
+	}
+	if exporter := strings.ToLower(c.Tracing.Exporter); exporter != "none" && exporter != "otlp" && exporter != "stdout" {
+		return fmt.Errorf("tracing.exporter: unknown exporter %s", c.Tracing.Exporter)
+	}
+
+	if c.Shutdown.Delay < 0 || c.Shutdown.Timeout <= 0 {
+		return errors.New("shutdown.delay must not be negative and shutdown.timeout must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+)
+
+// Headers browsers may send to, and read from, the HTTP API across origins.
+const (
+	corsAllowHeaders  = "Accept, Authorization, Content-Type, X-API-Key, X-Request-ID"
+	corsExposeHeaders = "Retry-After, WWW-Authenticate, X-Request-ID"
+)
+
+// originAllowed reports whether pages of origin may call the APIs, origins
+// holding "*" allowing every origin.
+func originAllowed(origins []string, origin string) bool {
+	for _, allowed := range origins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// CORSMiddleware lets pages of origins call the HTTP API from browsers,
+// answering preflight requests itself, before they are authenticated. Requests
+// from other origins are served without CORS headers, so browsers do not let
+// their pages read the responses.
+func CORSMiddleware(origins []string) app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		origin := string(c.GetHeader("Origin"))
+		if origin == "" || !originAllowed(origins, origin) {
+			c.Next(ctx)
+			return
+		}
+
+		c.Header("Access-Control-Allow-Origin", origin)
+		c.Header("Access-Control-Expose-Headers", corsExposeHeaders)
+		if string(c.Method()) == consts.MethodOptions && len(c.GetHeader("Access-Control-Request-Method")) > 0 {
+			c.Header("Access-Control-Allow-Methods", "GET, POST")
+			c.Header("Access-Control-Allow-Headers", corsAllowHeaders)
+			c.Header("Access-Control-Max-Age", "600")
+			c.AbortWithStatus(consts.StatusNoContent)
+			return
+		}
+		c.Next(ctx)
+		// Handlers set Vary themselves, so the origin is added after them
+		c.Response.Header.Add("Vary", "Origin")
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	engine := route.NewEngine(config.NewOptions(nil))
+	engine.Use(CORSMiddleware([]string{"https://app.example.com"}))
+	api := engine.Group("/api", AuthMiddleware(ChainAuthenticator{NewAPIKeyAuthenticator(map[string]string{"secret": "a"})}))
+	api.GET("/whoami", func(ctx context.Context, c *app.RequestContext) {
+		c.Header("Vary", "Accept")
+		c.String(consts.StatusOK, GetPrincipal(c).ID)
+	})
+
+	// Preflight requests are answered before authentication
+	resp := ut.PerformRequest(engine, consts.MethodOptions, "/api/whoami", nil,
+		ut.Header{Key: "Origin", Value: "https://app.example.com"},
+		ut.Header{Key: "Access-Control-Request-Method", Value: consts.MethodGet},
+	).Result()
+	assert.Equal(t, consts.StatusNoContent, resp.StatusCode())
+	assert.Equal(t, "https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, resp.Header.Get("Access-Control-Allow-Headers"), "X-API-Key")
+
+	resp = ut.PerformRequest(engine, consts.MethodGet, "/api/whoami", nil,
+		ut.Header{Key: "Origin", Value: "https://app.example.com"},
+		ut.Header{Key: "X-API-Key", Value: "secret"},
+	).Result()
+	assert.Equal(t, consts.StatusOK, resp.StatusCode())
+	assert.Equal(t, "https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, resp.Header.Get("Access-Control-Expose-Headers"), "X-Request-ID")
+	assert.ElementsMatch(t, []string{"Accept", "Origin"}, resp.Header.GetAll("Vary"))
+
+	// Other origins are served without CORS headers
+	resp = ut.PerformRequest(engine, consts.MethodGet, "/api/whoami", nil,
+		ut.Header{Key: "Origin", Value: "https://attacker.example"},
+		ut.Header{Key: "X-API-Key", Value: "secret"},
+	).Result()
+	assert.Equal(t, consts.StatusOK, resp.StatusCode())
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+	resp = ut.PerformRequest(engine, consts.MethodOptions, "/api/whoami", nil,
+		ut.Header{Key: "Origin", Value: "https://attacker.example"},
+		ut.Header{Key: "Access-Control-Request-Method", Value: consts.MethodGet},
+	).Result()
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+
+	assert.True(t, originAllowed([]string{"*"}, "https://any.example"))
+	assert.False(t, originAllowed(nil, "https://app.example.com"))
+}
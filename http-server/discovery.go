@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	etcd "github.com/kitex-contrib/registry-etcd"
+)
+
+// NewResolverFromConfig returns the resolver for the configured service
+// discovery.
+func NewResolverFromConfig(cfg DiscoveryConfig) (discovery.Resolver, error) {
+	switch cfg.Type {
+	case "etcd":
+		return etcd.NewEtcdResolver(cfg.EtcdEndpoints)
+	case "static":
+		return NewStaticResolver(cfg.StaticAddrs)
+	case "dns":
+		return &DNSResolver{Record: cfg.DNSRecord, Resolver: net.DefaultResolver}, nil
+	default:
+		return nil, fmt.Errorf("unknown discovery %s", cfg.Type)
+	}
+}
+
+// StaticResolver resolves every service to a fixed list of addresses.
+type StaticResolver struct {
+	instances []discovery.Instance
+}
+
+func NewStaticResolver(addrs []string) (*StaticResolver, error) {
+	if len(addrs) == 0 {
+		return nil, errors.New("no static addresses")
+	}
+
+	resolver := &StaticResolver{}
+	for _, addr := range addrs {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return nil, fmt.Errorf("invalid static address %s: %w", addr, err)
+		}
+		resolver.instances = append(resolver.instances, discovery.NewInstance("tcp", addr, discovery.DefaultWeight, nil))
+	}
+	return resolver, nil
+}
+
+func (r *StaticResolver) Target(ctx context.Context, target rpcinfo.EndpointInfo) string {
+	return target.ServiceName()
+}
+
+func (r *StaticResolver) Resolve(ctx context.Context, desc string) (discovery.Result, error) {
+	return discovery.Result{Cacheable: true, CacheKey: desc, Instances: r.instances}, nil
+}
+
+func (r *StaticResolver) Diff(cacheKey string, prev, next discovery.Result) (discovery.Change, bool) {
+	return discovery.DefaultDiff(cacheKey, prev, next)
+}
+
+func (r *StaticResolver) Name() string {
+	return "static"
+}
+
+// DNSResolver resolves every service through DNS. A Record beginning with an
+// underscore, such as "_rpc._tcp.rpc-server.internal", is looked up as an SRV
+// record, giving the port and weight of each instance. Any other Record must be
+// a host and port, such as "rpc-server:8888", whose A and AAAA records give the
+// instances.
+type DNSResolver struct {
+	Record   string
+	Resolver HostLookup
+}
+
+// HostLookup looks up the DNS records DNSResolver resolves services with.
+// net.DefaultResolver implements it.
+type HostLookup interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+func (r *DNSResolver) Target(ctx context.Context, target rpcinfo.EndpointInfo) string {
+	return target.ServiceName()
+}
+
+func (r *DNSResolver) Resolve(ctx context.Context, desc string) (discovery.Result, error) {
+	var instances []discovery.Instance
+	if strings.HasPrefix(r.Record, "_") {
+		_, records, err := r.Resolver.LookupSRV(ctx, "", "", r.Record)
+		if err != nil {
+			return discovery.Result{}, err
+		}
+		for _, record := range records {
+			weight := int(record.Weight)
+			if weight == 0 {
+				weight = discovery.DefaultWeight
+			}
+			addr := net.JoinHostPort(strings.TrimSuffix(record.Target, "."), strconv.Itoa(int(record.Port)))
+			instances = append(instances, discovery.NewInstance("tcp", addr, weight, nil))
+		}
+	} else {
+		host, port, err := net.SplitHostPort(r.Record)
+		if err != nil {
+			return discovery.Result{}, err
+		}
+		addrs, err := r.Resolver.LookupHost(ctx, host)
+		if err != nil {
+			return discovery.Result{}, err
+		}
+		for _, addr := range addrs {
+			instances = append(instances, discovery.NewInstance("tcp", net.JoinHostPort(addr, port), discovery.DefaultWeight, nil))
+		}
+	}
+
+	return discovery.Result{Cacheable: true, CacheKey: desc, Instances: instances}, nil
+}
+
+func (r *DNSResolver) Diff(cacheKey string, prev, next discovery.Result) (discovery.Change, bool) {
+	return discovery.DefaultDiff(cacheKey, prev, next)
+}
+
+func (r *DNSResolver) Name() string {
+	return "dns"
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeLookup answers DNS lookups from fixed records.
+type fakeLookup struct {
+	srv   map[string][]*net.SRV
+	hosts map[string][]string
+}
+
+func (l *fakeLookup) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	records, ok := l.srv[name]
+	if !ok {
+		return "", nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return name, records, nil
+}
+
+func (l *fakeLookup) LookupHost(ctx context.Context, host string) ([]string, error) {
+	addrs, ok := l.hosts[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return addrs, nil
+}
+
+func instanceAddrs(instances []discovery.Instance) map[string]int {
+	addrs := make(map[string]int)
+	for _, instance := range instances {
+		addrs[instance.Address().String()] = instance.Weight()
+	}
+	return addrs
+}
+
+func TestStaticResolver(t *testing.T) {
+	resolver, err := NewStaticResolver([]string{"10.0.0.1:8888", "rpc-server:8888"})
+	if assert.Nil(t, err) {
+		result, err := resolver.Resolve(context.Background(), "rpc-server")
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"10.0.0.1:8888": discovery.DefaultWeight, "rpc-server:8888": discovery.DefaultWeight}, instanceAddrs(result.Instances))
+	}
+
+	_, err = NewStaticResolver(nil)
+	assert.NotNil(t, err)
+	_, err = NewStaticResolver([]string{"10.0.0.1:8888", "rpc-server"})
+	assert.ErrorContains(t, err, "invalid static address rpc-server")
+}
+
+func TestDNSResolver(t *testing.T) {
+	lookup := &fakeLookup{
+		srv: map[string][]*net.SRV{
+			"_rpc._tcp.rpc-server.internal": {
+				{Target: "rpc-1.internal.", Port: 8888, Weight: 30},
+				{Target: "rpc-2.internal.", Port: 9999, Weight: 0},
+			},
+		},
+		hosts: map[string][]string{
+			"rpc-server": {"10.0.0.1", "fd00::1"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		record string
+		addrs  map[string]int
+		err    bool
+	}{
+		{
+			name:   "SRV record",
+			record: "_rpc._tcp.rpc-server.internal",
+			addrs:  map[string]int{"rpc-1.internal:8888": 30, "rpc-2.internal:9999": discovery.DefaultWeight},
+		},
+		{
+			name:   "host and port",
+			record: "rpc-server:8888",
+			addrs:  map[string]int{"10.0.0.1:8888": discovery.DefaultWeight, "[fd00::1]:8888": discovery.DefaultWeight},
+		},
+		{name: "unknown SRV record", record: "_rpc._tcp.other.internal", err: true},
+		{name: "unknown host", record: "other:8888", err: true},
+		{name: "host without port", record: "rpc-server", err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolver := &DNSResolver{Record: test.record, Resolver: lookup}
+			result, err := resolver.Resolve(context.Background(), "rpc-server")
+			if test.err {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.addrs, instanceAddrs(result.Instances))
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/common/logging"
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/kitex_gen/rpc"
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/proto_gen/api"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"google.golang.org/grpc/codes"
+)
+
+// errorStatuses maps the error codes of the IDL to the HTTP status they are
+// returned with. Codes not listed are returned as 500 Internal Server Error.
+var errorStatuses = map[rpc.ErrorCode]int{
+	rpc.ErrorCode_INVALID_ARGUMENT:  consts.StatusBadRequest,
+	rpc.ErrorCode_INVALID_LIMIT:     consts.StatusBadRequest,
+	rpc.ErrorCode_INVALID_CURSOR:    consts.StatusBadRequest,
+	rpc.ErrorCode_MESSAGE_REJECTED:  consts.StatusBadRequest,
+	rpc.ErrorCode_UNAUTHENTICATED:   consts.StatusUnauthorized,
+	rpc.ErrorCode_PERMISSION_DENIED: consts.StatusForbidden,
+	rpc.ErrorCode_BLOCKED:           consts.StatusForbidden,
+	rpc.ErrorCode_NOT_FOUND:         consts.StatusNotFound,
+	rpc.ErrorCode_CONFLICT:          consts.StatusConflict,
+	rpc.ErrorCode_RATE_LIMITED:      consts.StatusTooManyRequests,
+	rpc.ErrorCode_INTERNAL:          consts.StatusInternalServerError,
+	rpc.ErrorCode_UNAVAILABLE:       consts.StatusServiceUnavailable,
+}
+
+// HTTPStatus returns the HTTP status an error code is returned with.
+func HTTPStatus(code rpc.ErrorCode) int {
+	if status, ok := errorStatuses[code]; ok {
+		return status
+	}
+	return consts.StatusInternalServerError
+}
+
+// grpcCodes maps the error codes of the IDL to the gRPC status code they are
+// returned with. Codes not listed are returned as Internal.
+var grpcCodes = map[rpc.ErrorCode]codes.Code{
+	rpc.ErrorCode_INVALID_ARGUMENT:  codes.InvalidArgument,
+	rpc.ErrorCode_INVALID_LIMIT:     codes.InvalidArgument,
+	rpc.ErrorCode_INVALID_CURSOR:    codes.InvalidArgument,
+	rpc.ErrorCode_MESSAGE_REJECTED:  codes.InvalidArgument,
+	rpc.ErrorCode_UNAUTHENTICATED:   codes.Unauthenticated,
+	rpc.ErrorCode_PERMISSION_DENIED: codes.PermissionDenied,
+	rpc.ErrorCode_BLOCKED:           codes.PermissionDenied,
+	rpc.ErrorCode_NOT_FOUND:         codes.NotFound,
+	rpc.ErrorCode_CONFLICT:          codes.AlreadyExists,
+	rpc.ErrorCode_RATE_LIMITED:      codes.ResourceExhausted,
+	rpc.ErrorCode_INTERNAL:          codes.Internal,
+	rpc.ErrorCode_UNAVAILABLE:       codes.Unavailable,
+}
+
+// GRPCCode returns the gRPC status code an error code is returned with.
+func GRPCCode(code rpc.ErrorCode) codes.Code {
+	if grpcCode, ok := grpcCodes[code]; ok {
+		return grpcCode
+	}
+	return codes.Internal
+}
+
+// writeError responds with an api.Error carrying the code, message and ID of
+// the request, encoded as the client prefers.
+func writeError(ctx context.Context, c *app.RequestContext, code rpc.ErrorCode, message string) {
+	body := &api.Error{
+		Code:      int32(code),
+		Message:   message,
+		RequestId: logging.GetRequestID(ctx),
+	}
+	render(c, HTTPStatus(code), body, body)
+}
+
+// responseError responds with the error code and message of a failed RPC
+// response.
+func responseError(ctx context.Context, c *app.RequestContext, resp codedResponse) {
+	writeError(ctx, c, rpc.ErrorCode(resp.GetCode()), resp.GetMsg())
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/kitex_gen/rpc"
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/proto_gen/api"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestErrorCodes(t *testing.T) {
+	tests := []struct {
+		code     rpc.ErrorCode
+		status   int
+		grpcCode codes.Code
+	}{
+		{rpc.ErrorCode_SUCCESS, consts.StatusInternalServerError, codes.Internal},
+		{rpc.ErrorCode_INTERNAL, consts.StatusInternalServerError, codes.Internal},
+		{rpc.ErrorCode_INVALID_ARGUMENT, consts.StatusBadRequest, codes.InvalidArgument},
+		{rpc.ErrorCode_INVALID_LIMIT, consts.StatusBadRequest, codes.InvalidArgument},
+		{rpc.ErrorCode_INVALID_CURSOR, consts.StatusBadRequest, codes.InvalidArgument},
+		{rpc.ErrorCode_PERMISSION_DENIED, consts.StatusForbidden, codes.PermissionDenied},
+		{rpc.ErrorCode_RATE_LIMITED, consts.StatusTooManyRequests, codes.ResourceExhausted},
+		{rpc.ErrorCode_BLOCKED, consts.StatusForbidden, codes.PermissionDenied},
+		{rpc.ErrorCode_MESSAGE_REJECTED, consts.StatusBadRequest, codes.InvalidArgument},
+		{rpc.ErrorCode_CONFLICT, consts.StatusConflict, codes.AlreadyExists},
+		{rpc.ErrorCode_NOT_FOUND, consts.StatusNotFound, codes.NotFound},
+		{rpc.ErrorCode_UNAVAILABLE, consts.StatusServiceUnavailable, codes.Unavailable},
+		{rpc.ErrorCode_UNAUTHENTICATED, consts.StatusUnauthorized, codes.Unauthenticated},
+		{rpc.ErrorCode(99), consts.StatusInternalServerError, codes.Internal},
+	}
+	for _, test := range tests {
+		t.Run(test.code.String(), func(t *testing.T) {
+			assert.Equal(t, test.status, HTTPStatus(test.code))
+			assert.Equal(t, test.grpcCode, GRPCCode(test.code))
+		})
+	}
+
+	// Codes added to the IDL must be added above
+	tested := make(map[rpc.ErrorCode]bool)
+	for _, test := range tests {
+		tested[test.code] = true
+	}
+	for code := rpc.ErrorCode(-10); code < 100; code++ {
+		if code.String() != "<UNSET>" {
+			assert.True(t, tested[code], "code %s is not tested", code)
+		}
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	engine := route.NewEngine(config.NewOptions(nil))
+	engine.Use(RequestIDMiddleware)
+	engine.GET("/fail", func(ctx context.Context, c *app.RequestContext) {
+		writeError(ctx, c, rpc.ErrorCode_NOT_FOUND, "chat not found")
+	})
+	requestID := ut.Header{Key: "X-Request-ID", Value: "req-1"}
+
+	resp := ut.PerformRequest(engine, "GET", "/fail", nil, requestID).Result()
+	assert.Equal(t, consts.StatusNotFound, resp.StatusCode())
+	assert.Equal(t, "req-1", resp.Header.Get("X-Request-ID"))
+	var body map[string]interface{}
+	if assert.Nil(t, json.Unmarshal(resp.Body(), &body)) {
+		assert.Equal(t, map[string]interface{}{"code": float64(rpc.ErrorCode_NOT_FOUND), "message": "chat not found", "request_id": "req-1"}, body)
+	}
+
+	resp = ut.PerformRequest(engine, "GET", "/fail", nil, requestID, ut.Header{Key: "Accept", Value: protobufContentType}).Result()
+	assert.Equal(t, consts.StatusNotFound, resp.StatusCode())
+	msg := new(api.Error)
+	if assert.Nil(t, proto.Unmarshal(resp.Body(), msg)) {
+		assert.Equal(t, int32(rpc.ErrorCode_NOT_FOUND), msg.GetCode())
+		assert.Equal(t, "chat not found", msg.GetMessage())
+		assert.Equal(t, "req-1", msg.GetRequestId())
+	}
+}
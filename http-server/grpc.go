@@ -0,0 +1,393 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/common/logging"
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/kitex_gen/rpc"
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/proto_gen/api"
+	"github.com/bytedance/gopkg/cloud/metainfo"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"golang.org/x/exp/slog"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Metadata key of the request ID, the gRPC counterpart of the X-Request-ID header.
+const requestIDMetadataKey = "x-request-id"
+
+// NewGRPCServer returns the gRPC server of the MessageService in
+// idl_http.proto, authenticating calls with authenticator unless it is nil.
+func NewGRPCServer(authenticator Authenticator) *grpc.Server {
+	interceptors := []grpc.UnaryServerInterceptor{GRPCRequestIDInterceptor, GRPCTracingInterceptor, GRPCObservabilityInterceptor}
+	if authenticator != nil {
+		interceptors = append(interceptors, GRPCAuthInterceptor(authenticator))
+	}
+
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+	api.RegisterMessageServiceServer(server, &messageServer{})
+	return server
+}
+
+// NewGRPCHandler returns the handler serving server to both gRPC clients, over
+// cleartext HTTP/2, and gRPC-Web clients such as browsers, over HTTP/1.1 or 2.
+// As for the HTTP API, only pages of origins may call it from browsers.
+func NewGRPCHandler(server *grpc.Server, origins []string) http.Handler {
+	web := grpcweb.WrapServer(server,
+		grpcweb.WithOriginFunc(func(origin string) bool { return originAllowed(origins, origin) }),
+	)
+	return h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if web.IsGrpcWebRequest(r) || web.IsAcceptableGrpcCorsRequest(r) {
+			web.ServeHTTP(w, r)
+			return
+		}
+		server.ServeHTTP(w, r)
+	}), &http2.Server{})
+}
+
+// GRPCRequestIDInterceptor is the gRPC counterpart of RequestIDMiddleware,
+// reading and returning the request ID in the x-request-id metadata.
+func GRPCRequestIDInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var requestID string
+	if values := metadata.ValueFromIncomingContext(ctx, requestIDMetadataKey); len(values) > 0 {
+		requestID = values[0]
+	}
+	if !requestIDPattern.MatchString(requestID) {
+		requestID = newRequestID()
+	}
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, requestID)); err != nil {
+		slog.WarnCtx(ctx, "failed to set request ID header", "error", err)
+	}
+	return handler(metainfo.WithValue(ctx, logging.RequestIDMetaKey, requestID), req)
+}
+
+// GRPCObservabilityInterceptor logs and records the metrics of every gRPC call,
+// as HTTPLoggingMiddleware and HTTPMetricsMiddleware do for HTTP requests.
+func GRPCObservabilityInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	duration := time.Since(start)
+
+	code := status.Code(err)
+	grpcRequestsTotal.WithLabelValues(info.FullMethod, code.String()).Inc()
+	grpcRequestDuration.WithLabelValues(info.FullMethod).Observe(duration.Seconds())
+	slog.InfoCtx(ctx, "grpc request handled",
+		slog.String("method", info.FullMethod),
+		slog.String("code", code.String()),
+		slog.Duration("duration", duration),
+	)
+	return resp, err
+}
+
+// GRPCAuthInterceptor is the gRPC counterpart of AuthMiddleware, rejecting
+// calls which cannot be authenticated and adding the principal to the context.
+func GRPCAuthInterceptor(authenticator Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		// Authenticators read credentials from the headers of HTTP requests, which
+		// are the metadata of gRPC calls
+		var c app.RequestContext
+		md, _ := metadata.FromIncomingContext(ctx)
+		for key, values := range md {
+			for _, value := range values {
+				c.Request.Header.Add(key, value)
+			}
+		}
+
+		principal, err := authenticator.Authenticate(ctx, &c)
+		if err != nil {
+			return nil, grpcError(ctx, rpc.ErrorCode_UNAUTHENTICATED, err.Error())
+		}
+		return handler(context.WithValue(ctx, principalCtxKeyType{}, principal), req)
+	}
+}
+
+// grpcError returns the gRPC status of an error code, carrying an api.Error
+// with the code, message and ID of the request as a detail.
+func grpcError(ctx context.Context, code rpc.ErrorCode, message string) error {
+	return grpcRetryableError(ctx, code, message, 0)
+}
+
+// grpcRetryableError is grpcError with an additional RetryInfo detail telling
+// the client to wait for retryAfter before retrying, unless it is zero.
+func grpcRetryableError(ctx context.Context, code rpc.ErrorCode, message string, retryAfter time.Duration) error {
+	details := []protoiface.MessageV1{
+		&api.Error{Code: int32(code), Message: message, RequestId: logging.GetRequestID(ctx)},
+	}
+	if retryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	}
+
+	st := status.New(GRPCCode(code), message)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// grpcResponseError returns the gRPC status of a failed RPC response.
+func grpcResponseError(ctx context.Context, resp codedResponse) error {
+	return grpcError(ctx, rpc.ErrorCode(resp.GetCode()), resp.GetMsg())
+}
+
+// grpcRPCError is the gRPC counterpart of rpcError, returning Unavailable if the
+// rpc-server could not be reached and Internal otherwise.
+func grpcRPCError(ctx context.Context, method string, err error) error {
+	slog.ErrorCtx(ctx, "error calling rpc-server", "method", method, "error", err)
+	if IsUnavailable(err) {
+		return grpcRetryableError(ctx, rpc.ErrorCode_UNAVAILABLE, "message service unavailable, please retry later", time.Second)
+	}
+	return grpcError(ctx, rpc.ErrorCode_INTERNAL, "internal error")
+}
+
+// messageServer serves the MessageService over gRPC, forwarding calls to the
+// rpc-server with the same checks as the HTTP handlers.
+type messageServer struct {
+	api.UnimplementedMessageServiceServer
+}
+
+func (s *messageServer) Send(ctx context.Context, req *api.SendRequest) (*api.SendResponse, error) {
+	if !authorizePrincipal(principalFromContext(ctx), &req.Sender) {
+		return nil, grpcError(ctx, rpc.ErrorCode_PERMISSION_DENIED, "sender does not match authenticated identity")
+	}
+
+	resp, err := cli.Send(ctx, &rpc.SendRequest{
+		Message: newRPCMessage(req, time.Now().UTC().UnixMicro()),
+	})
+	if err != nil {
+		return nil, grpcRPCError(ctx, "Send", err)
+	} else if resp.Code == int32(rpc.ErrorCode_RATE_LIMITED) {
+		return nil, grpcRetryableError(ctx, rpc.ErrorCode_RATE_LIMITED, resp.Msg, time.Duration(resp.GetRetryAfter())*time.Millisecond)
+	} else if resp.Code != 0 {
+		return nil, grpcResponseError(ctx, resp)
+	}
+	return &api.SendResponse{}, nil
+}
+
+func (s *messageServer) Pull(ctx context.Context, req *api.PullRequest) (*api.PullResponse, error) {
+	if principal := principalFromContext(ctx); principal != nil && !IsChatMember(req.Chat, principal.ID) {
+		return nil, grpcError(ctx, rpc.ErrorCode_PERMISSION_DENIED, "not a member of chat")
+	}
+
+	resp, err := cli.Pull(ctx, &rpc.PullRequest{
+		Chat:    req.Chat,
+		Cursor:  req.Cursor,
+		Limit:   req.Limit,
+		Reverse: &req.Reverse,
+	})
+	if err != nil {
+		return nil, grpcRPCError(ctx, "Pull", err)
+	} else if resp.Code != 0 {
+		return nil, grpcResponseError(ctx, resp)
+	}
+	return &api.PullResponse{
+		Messages:   newAPIMessages(resp.Messages),
+		HasMore:    resp.GetHasMore(),
+		NextCursor: resp.GetNextCursor(),
+	}, nil
+}
+
+func (s *messageServer) BatchSend(ctx context.Context, req *api.BatchSendRequest) (*api.BatchSendResponse, error) {
+	// Messages are a microsecond apart so that they keep the order they were sent in
+	sendTime := time.Now().UTC().UnixMicro()
+	principal := principalFromContext(ctx)
+	messages := make([]*rpc.Message, 0, len(req.Messages))
+	for i, message := range req.Messages {
+		if !authorizePrincipal(principal, &message.Sender) {
+			return nil, grpcError(ctx, rpc.ErrorCode_PERMISSION_DENIED, fmt.Sprintf("sender of message %d does not match authenticated identity", i))
+		}
+		messages = append(messages, newRPCMessage(message, sendTime+int64(i)))
+	}
+
+	resp, err := cli.BatchSend(ctx, &rpc.BatchSendRequest{
+		Messages: messages,
+	})
+	if err != nil {
+		return nil, grpcRPCError(ctx, "BatchSend", err)
+	} else if resp.Code != 0 {
+		return nil, grpcResponseError(ctx, resp)
+	}
+	return &api.BatchSendResponse{Statuses: newAPISendStatuses(resp.Statuses)}, nil
+}
+
+func (s *messageServer) MultiPull(ctx context.Context, req *api.MultiPullRequest) (*api.MultiPullResponse, error) {
+	resp, err := cli.MultiPull(ctx, &rpc.MultiPullRequest{
+		Chats:   req.Chats,
+		Limit:   req.Limit,
+		Reverse: &req.Reverse,
+	})
+	if err != nil {
+		return nil, grpcRPCError(ctx, "MultiPull", err)
+	} else if resp.Code != 0 {
+		return nil, grpcResponseError(ctx, resp)
+	}
+	denyChatPages(principalFromContext(ctx), resp.Chats)
+	return &api.MultiPullResponse{Chats: newAPIChatPages(ctx, resp.Chats)}, nil
+}
+
+func (s *messageServer) ListChats(ctx context.Context, req *api.ListChatsRequest) (*api.ListChatsResponse, error) {
+	if !authorizePrincipal(principalFromContext(ctx), &req.User) {
+		return nil, grpcError(ctx, rpc.ErrorCode_PERMISSION_DENIED, "user does not match authenticated identity")
+	}
+
+	resp, err := cli.ListChats(ctx, &rpc.ListChatsRequest{
+		User:   req.User,
+		Cursor: req.Cursor,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		return nil, grpcRPCError(ctx, "ListChats", err)
+	} else if resp.Code != 0 {
+		return nil, grpcResponseError(ctx, resp)
+	}
+	return &api.ListChatsResponse{
+		Chats:      newAPIChatSummaries(resp.Chats),
+		HasMore:    resp.GetHasMore(),
+		NextCursor: resp.GetNextCursor(),
+	}, nil
+}
+
+func (s *messageServer) Block(ctx context.Context, req *api.BlockRequest) (*api.BlockResponse, error) {
+	if !authorizePrincipal(principalFromContext(ctx), &req.User) {
+		return nil, grpcError(ctx, rpc.ErrorCode_PERMISSION_DENIED, "user does not match authenticated identity")
+	}
+
+	resp, err := cli.Block(ctx, &rpc.BlockRequest{
+		User:    req.User,
+		Blocked: req.Blocked,
+	})
+	if err != nil {
+		return nil, grpcRPCError(ctx, "Block", err)
+	} else if resp.Code != 0 {
+		return nil, grpcResponseError(ctx, resp)
+	}
+	return &api.BlockResponse{}, nil
+}
+
+func (s *messageServer) Unblock(ctx context.Context, req *api.UnblockRequest) (*api.UnblockResponse, error) {
+	if !authorizePrincipal(principalFromContext(ctx), &req.User) {
+		return nil, grpcError(ctx, rpc.ErrorCode_PERMISSION_DENIED, "user does not match authenticated identity")
+	}
+
+	resp, err := cli.Unblock(ctx, &rpc.UnblockRequest{
+		User:    req.User,
+		Blocked: req.Blocked,
+	})
+	if err != nil {
+		return nil, grpcRPCError(ctx, "Unblock", err)
+	} else if resp.Code != 0 {
+		return nil, grpcResponseError(ctx, resp)
+	}
+	return &api.UnblockResponse{}, nil
+}
+
+func (s *messageServer) ListBlocked(ctx context.Context, req *api.ListBlockedRequest) (*api.ListBlockedResponse, error) {
+	if !authorizePrincipal(principalFromContext(ctx), &req.User) {
+		return nil, grpcError(ctx, rpc.ErrorCode_PERMISSION_DENIED, "user does not match authenticated identity")
+	}
+
+	resp, err := cli.ListBlocked(ctx, &rpc.ListBlockedRequest{
+		User: req.User,
+	})
+	if err != nil {
+		return nil, grpcRPCError(ctx, "ListBlocked", err)
+	} else if resp.Code != 0 {
+		return nil, grpcResponseError(ctx, resp)
+	}
+	return &api.ListBlockedResponse{Blocked: resp.GetBlocked()}, nil
+}
+
+func (s *messageServer) QueryAuditLog(ctx context.Context, req *api.QueryAuditLogRequest) (*api.QueryAuditLogResponse, error) {
+	resp, err := cli.QueryAuditLog(ctx, newRPCQueryAuditLogRequest(req))
+	if err != nil {
+		return nil, grpcRPCError(ctx, "QueryAuditLog", err)
+	} else if resp.Code != 0 {
+		return nil, grpcResponseError(ctx, resp)
+	}
+	return &api.QueryAuditLogResponse{
+		Entries:    newAPIAuditEntries(resp.Entries),
+		HasMore:    resp.GetHasMore(),
+		NextCursor: resp.GetNextCursor(),
+	}, nil
+}
+
+func (s *messageServer) ExportMessages(ctx context.Context, req *api.ExportMessagesRequest) (*api.ExportMessagesResponse, error) {
+	resp, err := cli.ExportMessages(ctx, newRPCExportMessagesRequest(req))
+	if err != nil {
+		return nil, grpcRPCError(ctx, "ExportMessages", err)
+	} else if resp.Code != 0 {
+		return nil, grpcResponseError(ctx, resp)
+	}
+	return &api.ExportMessagesResponse{
+		Messages:   newAPIMessages(resp.Messages),
+		HasMore:    resp.GetHasMore(),
+		NextCursor: resp.GetNextCursor(),
+	}, nil
+}
+
+func (s *messageServer) ListFlags(ctx context.Context, req *api.ListFlagsRequest) (*api.ListFlagsResponse, error) {
+	resp, err := cli.ListFlags(ctx, newRPCListFlagsRequest(req))
+	if err != nil {
+		return nil, grpcRPCError(ctx, "ListFlags", err)
+	} else if resp.Code != 0 {
+		return nil, grpcResponseError(ctx, resp)
+	}
+	return &api.ListFlagsResponse{
+		Flags:      newAPIModerationFlags(resp.Flags),
+		HasMore:    resp.GetHasMore(),
+		NextCursor: resp.GetNextCursor(),
+	}, nil
+}
+
+func (s *messageServer) ReviewFlag(ctx context.Context, req *api.ReviewFlagRequest) (*api.ReviewFlagResponse, error) {
+	resp, err := cli.ReviewFlag(ctx, newRPCReviewFlagRequest(req))
+	if err != nil {
+		return nil, grpcRPCError(ctx, "ReviewFlag", err)
+	} else if resp.Code != 0 {
+		return nil, grpcResponseError(ctx, resp)
+	}
+	return &api.ReviewFlagResponse{}, nil
+}
+
+func (s *messageServer) PublishKey(ctx context.Context, req *api.PublishKeyRequest) (*api.PublishKeyResponse, error) {
+	if !authorizePrincipal(principalFromContext(ctx), &req.User) {
+		return nil, grpcError(ctx, rpc.ErrorCode_PERMISSION_DENIED, "user does not match authenticated identity")
+	}
+
+	resp, err := cli.PublishKey(ctx, &rpc.PublishKeyRequest{
+		Key: &rpc.IdentityKey{
+			User:      req.User,
+			KeyId:     req.KeyId,
+			Algorithm: req.Algorithm,
+			PublicKey: req.PublicKey,
+		},
+	})
+	if err != nil {
+		return nil, grpcRPCError(ctx, "PublishKey", err)
+	} else if resp.Code != 0 {
+		return nil, grpcResponseError(ctx, resp)
+	}
+	return &api.PublishKeyResponse{}, nil
+}
+
+func (s *messageServer) GetKeys(ctx context.Context, req *api.GetKeysRequest) (*api.GetKeysResponse, error) {
+	resp, err := cli.GetKeys(ctx, &rpc.GetKeysRequest{
+		User: req.User,
+	})
+	if err != nil {
+		return nil, grpcRPCError(ctx, "GetKeys", err)
+	} else if resp.Code != 0 {
+		return nil, grpcResponseError(ctx, resp)
+	}
+	return &api.GetKeysResponse{Keys: newAPIIdentityKeys(resp.Keys)}, nil
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/kitex_gen/rpc"
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/proto_gen/api"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// startTestGRPCServer serves the MessageService over an in-memory connection,
+// forwarding to stub, until the test ends, returning a client of it.
+func startTestGRPCServer(t *testing.T, stub *stubIMClient, authenticator Authenticator) api.MessageServiceClient {
+	useTestClient(t, stub)
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewGRPCServer(authenticator)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Error when connecting to test gRPC server: %+v\n", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return api.NewMessageServiceClient(conn)
+}
+
+// errorDetails returns the api.Error and RetryInfo details of a gRPC error.
+func errorDetails(err error) (*api.Error, *errdetails.RetryInfo) {
+	var apiErr *api.Error
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		switch detail := detail.(type) {
+		case *api.Error:
+			apiErr = detail
+		case *errdetails.RetryInfo:
+			retryInfo = detail
+		}
+	}
+	return apiErr, retryInfo
+}
+
+func TestGRPCServer_Errors(t *testing.T) {
+	authenticator := ChainAuthenticator{NewAPIKeyAuthenticator(map[string]string{"secret": "a"})}
+
+	tests := []struct {
+		name       string
+		stub       *stubIMClient
+		key        string
+		req        *api.SendRequest
+		code       codes.Code
+		errCode    rpc.ErrorCode
+		retryAfter time.Duration
+	}{
+		{
+			name:    "unauthenticated",
+			stub:    &stubIMClient{},
+			req:     &api.SendRequest{Chat: "a:b", Text: "hi", Sender: "a"},
+			code:    codes.Unauthenticated,
+			errCode: rpc.ErrorCode_UNAUTHENTICATED,
+		},
+		{
+			name:    "sender mismatch",
+			stub:    &stubIMClient{},
+			key:     "secret",
+			req:     &api.SendRequest{Chat: "a:b", Text: "hi", Sender: "b"},
+			code:    codes.PermissionDenied,
+			errCode: rpc.ErrorCode_PERMISSION_DENIED,
+		},
+		{
+			name:       "rate limited",
+			stub:       &stubIMClient{retryAfter: 1500},
+			key:        "secret",
+			req:        &api.SendRequest{Chat: "a:b", Text: "hi", Sender: "a"},
+			code:       codes.ResourceExhausted,
+			errCode:    rpc.ErrorCode_RATE_LIMITED,
+			retryAfter: 1500 * time.Millisecond,
+		},
+		{
+			name:    "rejected",
+			stub:    &stubIMClient{},
+			key:     "secret",
+			req:     &api.SendRequest{Chat: "a:b", Sender: "a"},
+			code:    codes.InvalidArgument,
+			errCode: rpc.ErrorCode_INVALID_ARGUMENT,
+		},
+		{
+			name: "sent",
+			stub: &stubIMClient{},
+			key:  "secret",
+			req:  &api.SendRequest{Chat: "a:b", Text: "hi", Sender: "a"},
+			code: codes.OK,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := startTestGRPCServer(t, test.stub, authenticator)
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-1")
+			if test.key != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", test.key)
+			}
+
+			var header metadata.MD
+			_, err := client.Send(ctx, test.req, grpc.Header(&header))
+			assert.Equal(t, test.code, status.Code(err))
+			assert.Equal(t, []string{"req-1"}, header.Get("x-request-id"))
+			if test.code == codes.OK {
+				assert.Equal(t, 1, len(test.stub.sent()))
+				return
+			}
+
+			apiErr, retryInfo := errorDetails(err)
+			if assert.NotNil(t, apiErr) {
+				assert.Equal(t, int32(test.errCode), apiErr.Code)
+				assert.Equal(t, "req-1", apiErr.RequestId)
+			}
+			if test.retryAfter > 0 && assert.NotNil(t, retryInfo) {
+				assert.Equal(t, test.retryAfter, retryInfo.RetryDelay.AsDuration())
+			} else {
+				assert.Nil(t, retryInfo)
+			}
+		})
+	}
+}
+
+func TestGRPCServer_Unavailable(t *testing.T) {
+	client := startTestGRPCServer(t, &stubIMClient{pullErrors: []error{kerrors.ErrNoMoreInstance}}, nil)
+
+	_, err := client.Pull(context.Background(), &api.PullRequest{Chat: "a:b"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	apiErr, retryInfo := errorDetails(err)
+	if assert.NotNil(t, apiErr) {
+		assert.Equal(t, int32(rpc.ErrorCode_UNAVAILABLE), apiErr.Code)
+		assert.NotEmpty(t, apiErr.RequestId)
+	}
+	if assert.NotNil(t, retryInfo) {
+		assert.Equal(t, time.Second, retryInfo.RetryDelay.AsDuration())
+	}
+}
+
+func TestGRPCServer_ChatMembership(t *testing.T) {
+	stub := &stubIMClient{messages: []*rpc.Message{{Chat: "a:b", Text: "hi", Sender: "b"}}}
+	client := startTestGRPCServer(t, stub, ChainAuthenticator{NewAPIKeyAuthenticator(map[string]string{"secret": "a"})})
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "secret")
+
+	_, err := client.Pull(ctx, &api.PullRequest{Chat: "b:c"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	resp, err := client.MultiPull(ctx, &api.MultiPullRequest{Chats: []string{"a:b", "b:c"}})
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(resp.Chats)) {
+		assert.Nil(t, resp.Chats[0].Error)
+		assert.Equal(t, 1, len(resp.Chats[0].Messages))
+		if assert.NotNil(t, resp.Chats[1].Error) {
+			assert.Equal(t, int32(rpc.ErrorCode_PERMISSION_DENIED), resp.Chats[1].Error.Code)
+		}
+	}
+}
+
+func TestGRPCHandler_GRPCWeb(t *testing.T) {
+	stub := &stubIMClient{}
+	useTestClient(t, stub)
+	server := NewGRPCServer(nil)
+	t.Cleanup(server.Stop)
+	handler := NewGRPCHandler(server, []string{"https://example.com"})
+
+	msg, err := proto.Marshal(&api.SendRequest{Chat: "a:b", Text: "hi", Sender: "a"})
+	if err != nil {
+		t.Fatalf("Error when encoding test request: %+v\n", err)
+	}
+	// A gRPC-Web body is a length-prefixed message frame
+	frame := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	frame = append(frame, msg...)
+
+	req := httptest.NewRequest(http.MethodPost, "/api.MessageService/Send", bytes.NewReader(frame))
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+	req.Header.Set("Origin", "https://example.com")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "https://example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, recorder.Body.String(), "grpc-status: 0")
+	assert.Equal(t, 1, len(stub.sent()))
+
+	// Browsers are not let to read the responses of calls from other origins
+	req = httptest.NewRequest(http.MethodOptions, "/api.MessageService/Send", nil)
+	req.Header.Set("Origin", "https://attacker.example")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestGRPCServer_Tracing(t *testing.T) {
+	provider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+	})
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	client := startTestGRPCServer(t, &stubIMClient{}, nil)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, err := client.Send(ctx, &api.SendRequest{Chat: "a:b", Text: "hi", Sender: "a"})
+	assert.Nil(t, err)
+
+	// The server span continues the client's trace, and the RPC made is its child
+	var serverSpan sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == "api.MessageService/Send" {
+			serverSpan = span
+		}
+	}
+	if assert.NotNil(t, serverSpan) {
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", serverSpan.SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", serverSpan.Parent().SpanID().String())
+		assert.Equal(t, trace.SpanKindServer, serverSpan.SpanKind())
+		assert.Contains(t, serverSpan.Attributes(), semconv.RPCGRPCStatusCodeKey.Int(0))
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/utils"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"golang.org/x/exp/slog"
+)
+
+// readinessTimeout bounds how long a readiness check waits on service discovery,
+// so that an unreachable registry fails the check before the probe times out.
+const readinessTimeout = 2 * time.Second
+
+// HealthzHandler reports that the process is alive. It does not resolve the
+// rpc-server: when no rpc-server is up, restarting the gateway would not help.
+func HealthzHandler(ctx context.Context, c *app.RequestContext) {
+	c.JSON(consts.StatusOK, utils.H{"status": "ok"})
+}
+
+// ReadyzHandler reports whether this replica can serve requests, which requires
+// at least one instance of service to be resolvable and the server not to be
+// shutting down.
+func ReadyzHandler(resolver discovery.Resolver, service string) app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		if shuttingDown.Load() {
+			c.JSON(consts.StatusServiceUnavailable, utils.H{"status": "shutting down"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+		defer cancel()
+
+		result, err := resolver.Resolve(ctx, service)
+		if err != nil {
+			slog.WarnCtx(ctx, "readiness check failed", "check", service, "error", err)
+			c.JSON(consts.StatusServiceUnavailable, utils.H{"status": "unavailable", service: err.Error()})
+			return
+		}
+		if len(result.Instances) == 0 {
+			slog.WarnCtx(ctx, "readiness check failed", "check", service, "error", "no instances")
+			c.JSON(consts.StatusServiceUnavailable, utils.H{"status": "unavailable", service: "no instances"})
+			return
+		}
+		c.JSON(consts.StatusOK, utils.H{"status": "ok", service: len(result.Instances)})
+	}
+}
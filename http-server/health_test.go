@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubResolver resolves every service to instances, or fails with err.
+type stubResolver struct {
+	instances []discovery.Instance
+	err       error
+}
+
+func (r *stubResolver) Target(ctx context.Context, target rpcinfo.EndpointInfo) string {
+	return target.ServiceName()
+}
+
+func (r *stubResolver) Resolve(ctx context.Context, desc string) (discovery.Result, error) {
+	return discovery.Result{CacheKey: desc, Instances: r.instances}, r.err
+}
+
+func (r *stubResolver) Diff(cacheKey string, prev, next discovery.Result) (discovery.Change, bool) {
+	return discovery.DefaultDiff(cacheKey, prev, next)
+}
+
+func (r *stubResolver) Name() string {
+	return "stub"
+}
+
+func TestHealthHandlers(t *testing.T) {
+	instance := discovery.NewInstance("tcp", "127.0.0.1:8888", discovery.DefaultWeight, nil)
+
+	tests := []struct {
+		name         string
+		resolver     *stubResolver
+		shuttingDown bool
+		status       int
+		body         string
+	}{
+		{name: "ready", resolver: &stubResolver{instances: []discovery.Instance{instance}}, status: consts.StatusOK, body: `{"status": "ok", "rpc-server": 1}`},
+		{name: "no instances", resolver: &stubResolver{}, status: consts.StatusServiceUnavailable, body: `{"status": "unavailable", "rpc-server": "no instances"}`},
+		{name: "resolver error", resolver: &stubResolver{err: errors.New("registry down")}, status: consts.StatusServiceUnavailable, body: `{"status": "unavailable", "rpc-server": "registry down"}`},
+		{name: "shutting down", resolver: &stubResolver{instances: []discovery.Instance{instance}}, shuttingDown: true, status: consts.StatusServiceUnavailable, body: `{"status": "shutting down"}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shuttingDown.Store(test.shuttingDown)
+			defer shuttingDown.Store(false)
+			engine := route.NewEngine(config.NewOptions(nil))
+			engine.GET("/healthz", HealthzHandler)
+			engine.GET("/readyz", ReadyzHandler(test.resolver, "rpc-server"))
+
+			resp := ut.PerformRequest(engine, "GET", "/healthz", nil).Result()
+			assert.Equal(t, consts.StatusOK, resp.StatusCode())
+
+			resp = ut.PerformRequest(engine, "GET", "/readyz", nil).Result()
+			assert.Equal(t, test.status, resp.StatusCode())
+			assert.JSONEq(t, test.body, string(resp.Body()))
+		})
+	}
+}
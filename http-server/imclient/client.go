@@ -0,0 +1,312 @@
+// Package imclient is a client of the HTTP API of the IM service, sending and
+// pulling messages with retries and authentication.
+//
+//	client := imclient.New("http://localhost:8080", imclient.WithToken(token))
+//	err := client.Send(ctx, &imclient.SendRequest{Chat: "john:doe", Text: "hi"})
+//
+//	messages := client.Messages(ctx, imclient.PullRequest{Chat: "john:doe"})
+//	for messages.Next() {
+//		fmt.Println(messages.Message().Text)
+//	}
+//	if err := messages.Err(); err != nil {
+//		...
+//	}
+package imclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMaxRetries = 2
+	DefaultBackoff    = 100 * time.Millisecond
+)
+
+// Client calls the HTTP API of an http-server. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	token      string
+	apiKey     string
+	maxRetries int
+	backoff    time.Duration
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client requests are made with,
+// http.DefaultClient by default.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken authenticates requests with a JWT bearer token.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithAPIKey authenticates requests with an API key.
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithRetries sets the number of times a failed request is retried, zero
+// disabling retries, and the backoff before the first retry, which doubles
+// with each further retry. The server's Retry-After is waited for instead when
+// given.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// New returns a client of the http-server at baseURL, e.g.
+// "http://localhost:8080".
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		maxRetries: DefaultMaxRetries,
+		backoff:    DefaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Send sends a message. Only requests rejected for being rate limited are
+// retried, as a message may have been stored even if the request failed.
+func (c *Client) Send(ctx context.Context, req *SendRequest) error {
+	return c.do(ctx, http.MethodPost, "/api/send", false, req, nil)
+}
+
+// Pull pulls a page of the messages of a chat.
+func (c *Client) Pull(ctx context.Context, req *PullRequest) (*PullResponse, error) {
+	resp := new(PullResponse)
+	if err := c.do(ctx, http.MethodGet, "/api/pull", true, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListChats lists a page of the chats a user sent or received messages in.
+func (c *Client) ListChats(ctx context.Context, req *ListChatsRequest) (*ListChatsResponse, error) {
+	resp := new(ListChatsResponse)
+	if err := c.do(ctx, http.MethodGet, "/api/chats", true, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Export exports a page of the messages of a chat, or of every chat of a user.
+// Administrators may export any chat, and users the chats they are members of.
+// Only the first page of an export is not retried when the server was
+// unavailable, as the server records each export when its first page is
+// requested.
+func (c *Client) Export(ctx context.Context, req *ExportRequest) (*ExportResponse, error) {
+	resp := new(ExportResponse)
+	if err := c.do(ctx, http.MethodGet, "/api/export", req.Cursor != "", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Messages returns an iterator over the messages of a chat from req.Cursor,
+// pulling pages of req.Limit messages until there are no more.
+func (c *Client) Messages(ctx context.Context, req PullRequest) *MessageIterator {
+	return &MessageIterator{cursor: req.Cursor, next: func() ([]*Message, bool, error) {
+		resp, err := c.Pull(ctx, &req)
+		if err != nil {
+			return nil, false, err
+		}
+		req.Cursor = resp.NextCursor
+		return resp.Messages, resp.HasMore, nil
+	}}
+}
+
+// ExportMessages returns an iterator over the messages of an export from
+// req.Cursor, exporting pages of req.Limit messages until there are no more.
+// Unlike the offsets of Messages, the cursors of an export are positions of
+// messages, so messages sent during the export do not move the pages after
+// them.
+func (c *Client) ExportMessages(ctx context.Context, req ExportRequest) *MessageIterator {
+	return &MessageIterator{next: func() ([]*Message, bool, error) {
+		resp, err := c.Export(ctx, &req)
+		if err != nil {
+			return nil, false, err
+		}
+		req.Cursor = resp.NextCursor
+		return resp.Messages, resp.HasMore, nil
+	}}
+}
+
+// do makes a request with a JSON body of in, decoding the JSON response body
+// into out unless it is nil. Failed requests are retried if the server was
+// unavailable, only if idempotent, or rate limited.
+func (c *Client) do(ctx context.Context, method, path string, idempotent bool, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = c.attempt(ctx, method, path, body, out)
+		if err == nil || attempt >= c.maxRetries || !retryable(ctx, err, idempotent) {
+			return err
+		}
+
+		delay := c.backoff << attempt
+		var apiErr *Error
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			delay = apiErr.RetryAfter
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) attempt(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return newError(resp, respBody)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("imclient: failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// retryable reports whether a request failing with err may succeed if retried.
+// Requests which are not idempotent are only retried when rate limited, as they
+// are then known to have been rejected.
+func retryable(ctx context.Context, err error, idempotent bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		// The server could not be reached or the connection was lost
+		return idempotent
+	}
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+func newError(resp *http.Response, body []byte) *Error {
+	apiErr := &Error{StatusCode: resp.StatusCode}
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Message == "" {
+		// Not an error of the API, e.g. from a proxy in front of it
+		apiErr.Code = CodeInternal
+		apiErr.Message = strings.TrimSpace(string(body))
+		if apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = resp.Header.Get("X-Request-ID")
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return apiErr
+}
+
+// MessageIterator iterates over the messages of a chat or an export, requesting
+// the next page once the messages of the previous one have been iterated over.
+type MessageIterator struct {
+	// next returns the page after the previous one, and whether there are more
+	next    func() ([]*Message, bool, error)
+	page    []*Message
+	message *Message
+	cursor  int64
+	done    bool
+	err     error
+}
+
+// Next advances to the next message, returning false once there are no more
+// messages or pulling a page failed, which Err then returns.
+func (it *MessageIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		page, hasMore, err := it.next()
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page, it.done = page, !hasMore
+	}
+	it.message, it.page = it.page[0], it.page[1:]
+	it.cursor++
+	return true
+}
+
+// Message returns the current message.
+func (it *MessageIterator) Message() *Message {
+	return it.message
+}
+
+// Cursor returns the cursor following the current message, from which a new
+// iterator can continue once more messages have been sent. Only iterators
+// returned by Messages have one.
+func (it *MessageIterator) Cursor() int64 {
+	return it.cursor
+}
+
+// Err returns the error pulling a page failed with, if any.
+func (it *MessageIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,99 @@
+package imclient
+
+import (
+	"fmt"
+	"time"
+)
+
+// Error codes of the API, see ErrorCode in idl_rpc.thrift.
+const (
+	CodeInternal         int32 = -1
+	CodeInvalidArgument  int32 = 1
+	CodeInvalidLimit     int32 = 2
+	CodeInvalidCursor    int32 = 3
+	CodePermissionDenied int32 = 4
+	CodeRateLimited      int32 = 5
+	CodeBlocked          int32 = 6
+	CodeMessageRejected  int32 = 7
+	CodeConflict         int32 = 8
+	CodeNotFound         int32 = 9
+	CodeUnavailable      int32 = 10
+	CodeUnauthenticated  int32 = 11
+)
+
+type Message struct {
+	Chat           string `json:"chat"`                       // format "<member1>:<member2>", e.g. "john:doe"
+	Text           string `json:"text"`                       // message text content
+	Sender         string `json:"sender"`                     // sender identifier of the message
+	SendTime       int64  `json:"send_time"`                  // unit: microseconds
+	Encrypted      bool   `json:"encrypted,omitempty"`        // if true, text is an opaque end-to-end encrypted envelope
+	SenderKeyID    string `json:"sender_key_id,omitempty"`    // identity key of the sender used for the envelope
+	RecipientKeyID string `json:"recipient_key_id,omitempty"` // identity key of the receiver used for the envelope
+}
+
+type SendRequest struct {
+	Chat           string `json:"chat"`             // format "<member1>:<member2>", e.g. "john:doe"
+	Text           string `json:"text"`             // message text content to be sent
+	Sender         string `json:"sender,omitempty"` // defaults to the authenticated caller
+	Encrypted      bool   `json:"encrypted,omitempty"`
+	SenderKeyID    string `json:"sender_key_id,omitempty"`
+	RecipientKeyID string `json:"recipient_key_id,omitempty"`
+}
+
+type PullRequest struct {
+	Chat    string `json:"chat"`              // format "<member1>:<member2>", e.g. "john:doe"
+	Cursor  int64  `json:"cursor,omitempty"`  // starting position of the page, 0 by default
+	Limit   int32  `json:"limit,omitempty"`   // the maximum number of messages returned per page, 10 by default
+	Reverse bool   `json:"reverse,omitempty"` // if false, the results will be sorted in ascending order by time
+}
+
+type PullResponse struct {
+	Messages   []*Message `json:"messages"`
+	HasMore    bool       `json:"has_more"`    // if true, can use NextCursor to pull the next page of messages
+	NextCursor int64      `json:"next_cursor"` // starting position of next page
+}
+
+type ExportRequest struct {
+	Chat    string `json:"chat,omitempty"`    // chat to export, either Chat or User must be set
+	User    string `json:"user,omitempty"`    // user to export every chat of
+	Cursor  string `json:"cursor,omitempty"`  // NextCursor of the previous page, empty for the first page
+	Limit   int32  `json:"limit,omitempty"`   // the maximum number of messages returned per page, 500 by default
+	Reverse bool   `json:"reverse,omitempty"` // if true, chats and their messages are exported in descending order
+}
+
+type ExportResponse struct {
+	Messages   []*Message `json:"messages"`              // ordered by chat, then by send time
+	HasMore    bool       `json:"has_more"`              // if true, can use NextCursor to export the next page of messages
+	NextCursor string     `json:"next_cursor,omitempty"` // opaque position after the last message of the page
+}
+
+type ChatSummary struct {
+	Chat         string `json:"chat"`           // normalised chat
+	LastSendTime int64  `json:"last_send_time"` // send time of the latest message, unit: microseconds
+	MessageCount int64  `json:"message_count"`
+}
+
+type ListChatsRequest struct {
+	User   string `json:"user,omitempty"`   // defaults to the authenticated caller
+	Cursor int64  `json:"cursor,omitempty"` // starting position of the page, 0 by default
+	Limit  int32  `json:"limit,omitempty"`  // the maximum number of chats returned per page, 50 by default
+}
+
+type ListChatsResponse struct {
+	Chats      []*ChatSummary `json:"chats"`       // most recently active first
+	HasMore    bool           `json:"has_more"`    // if true, can use NextCursor to list the next page of chats
+	NextCursor int64          `json:"next_cursor"` // starting position of next page
+}
+
+// Error is the error of a request the API responded to with an error.
+type Error struct {
+	StatusCode int           `json:"-"`          // HTTP status of the response
+	Code       int32         `json:"code"`       // one of the Code constants
+	Message    string        `json:"message"`    // human readable description, not to be parsed
+	RequestID  string        `json:"request_id"` // ID of the request, to be quoted when reporting an issue
+	RetryAfter time.Duration `json:"-"`          // time to wait before retrying, if the server asked for one
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("imclient: %s (code %d, HTTP status %d, request ID %s)", e.Message, e.Code, e.StatusCode, e.RequestID)
+}
@@ -0,0 +1,371 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/imclient"
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/kitex_gen/rpc"
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/kitex_gen/rpc/imservice"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/kitex/client/callopt"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubIMClient is an in-memory rpc-server, storing the messages sent and
+// paginating them by offset as the rpc-server does.
+type stubIMClient struct {
+	imservice.Client
+
+	mu         sync.Mutex
+	messages   []*rpc.Message
+	pullErrors []error // returned by the next calls to Pull, in order
+	pulls      int
+	retryAfter int64 // if set, Send is rate limited for this many milliseconds
+}
+
+func (s *stubIMClient) Send(ctx context.Context, req *rpc.SendRequest, callOptions ...callopt.Option) (*rpc.SendResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Message.Text == "" {
+		return &rpc.SendResponse{Code: int32(rpc.ErrorCode_INVALID_ARGUMENT), Msg: "text is empty"}, nil
+	} else if s.retryAfter > 0 {
+		return &rpc.SendResponse{Code: int32(rpc.ErrorCode_RATE_LIMITED), Msg: "rate limited", RetryAfter: &s.retryAfter}, nil
+	}
+	s.messages = append(s.messages, req.Message)
+	return &rpc.SendResponse{}, nil
+}
+
+func (s *stubIMClient) Pull(ctx context.Context, req *rpc.PullRequest, callOptions ...callopt.Option) (*rpc.PullResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pulls++
+	if len(s.pullErrors) > 0 {
+		err := s.pullErrors[0]
+		s.pullErrors = s.pullErrors[1:]
+		return nil, err
+	}
+
+	var messages []*rpc.Message
+	for _, message := range s.messages {
+		if message.Chat == req.Chat {
+			messages = append(messages, message)
+		}
+	}
+	if req.GetReverse() {
+		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+			messages[i], messages[j] = messages[j], messages[i]
+		}
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = 10
+	}
+	resp := &rpc.PullResponse{Messages: []*rpc.Message{}}
+	if req.Cursor < int64(len(messages)) {
+		messages = messages[req.Cursor:]
+		if int32(len(messages)) > limit {
+			hasMore, nextCursor := true, req.Cursor+int64(limit)
+			resp.HasMore, resp.NextCursor = &hasMore, &nextCursor
+			messages = messages[:limit]
+		}
+		resp.Messages = messages
+	}
+	return resp, nil
+}
+
+// ExportMessages exports the messages of a chat as Pull does, with the index of
+// the next message as the cursor.
+func (s *stubIMClient) ExportMessages(ctx context.Context, req *rpc.ExportMessagesRequest, callOptions ...callopt.Option) (*rpc.ExportMessagesResponse, error) {
+	var cursor int64
+	if req.GetCursor() != "" {
+		var err error
+		if cursor, err = strconv.ParseInt(req.GetCursor(), 10, 64); err != nil {
+			return &rpc.ExportMessagesResponse{Code: int32(rpc.ErrorCode_INVALID_CURSOR), Msg: "invalid cursor"}, nil
+		}
+	}
+	pullResp, err := s.Pull(ctx, &rpc.PullRequest{Chat: req.GetChat(), Cursor: cursor, Limit: req.Limit, Reverse: req.Reverse})
+	if err != nil {
+		return nil, err
+	}
+	resp := &rpc.ExportMessagesResponse{Messages: pullResp.Messages, HasMore: pullResp.HasMore}
+	if pullResp.GetHasMore() {
+		nextCursor := strconv.FormatInt(pullResp.GetNextCursor(), 10)
+		resp.NextCursor = &nextCursor
+	}
+	return resp, nil
+}
+
+// failPulls makes the next calls to Pull return errs, in order, and resets the
+// count of calls.
+func (s *stubIMClient) failPulls(errs ...error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pullErrors = errs
+	s.pulls = 0
+}
+
+// pullCount returns the number of calls to Pull.
+func (s *stubIMClient) pullCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pulls
+}
+
+// sent returns the messages sent.
+func (s *stubIMClient) sent() []*rpc.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*rpc.Message(nil), s.messages...)
+}
+
+func (s *stubIMClient) MultiPull(ctx context.Context, req *rpc.MultiPullRequest, callOptions ...callopt.Option) (*rpc.MultiPullResponse, error) {
+	resp := &rpc.MultiPullResponse{}
+	for _, chat := range req.Chats {
+		if members := strings.Split(chat, ":"); len(members) != 2 || members[0] == "" || members[1] == "" {
+			resp.Chats = append(resp.Chats, &rpc.ChatPage{Chat: chat, Code: int32(rpc.ErrorCode_INVALID_ARGUMENT), Msg: "invalid chat ID"})
+			continue
+		}
+		pullResp, err := s.Pull(ctx, &rpc.PullRequest{Chat: chat, Limit: req.Limit, Reverse: req.Reverse})
+		if err != nil {
+			return nil, err
+		}
+		resp.Chats = append(resp.Chats, &rpc.ChatPage{Chat: chat, Msg: "success", Messages: pullResp.Messages, HasMore: pullResp.HasMore, NextCursor: pullResp.NextCursor})
+	}
+	return resp, nil
+}
+
+func (s *stubIMClient) ListChats(ctx context.Context, req *rpc.ListChatsRequest, callOptions ...callopt.Option) (*rpc.ListChatsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Most recently active first, ignoring pagination
+	var chats []*rpc.ChatSummary
+	seen := make(map[string]*rpc.ChatSummary)
+	for i := len(s.messages) - 1; i >= 0; i-- {
+		message := s.messages[i]
+		if !IsChatMember(message.Chat, req.User) {
+			continue
+		}
+		if chat, ok := seen[message.Chat]; ok {
+			chat.MessageCount++
+			continue
+		}
+		seen[message.Chat] = &rpc.ChatSummary{Chat: message.Chat, LastSendTime: message.SendTime, MessageCount: 1}
+		chats = append(chats, seen[message.Chat])
+	}
+	return &rpc.ListChatsResponse{Chats: chats}, nil
+}
+
+// useTestClient forwards the gateway's RPCs to stub until the test ends.
+func useTestClient(t *testing.T, stub imservice.Client) {
+	prev := cli
+	cli = stub
+	t.Cleanup(func() { cli = prev })
+}
+
+// startTestServer serves the HTTP API, forwarding to stub, until the test ends,
+// returning a constructor for clients of it. The server listens on a unix
+// socket in the test's temporary directory so that no other process can take
+// its address before it binds.
+func startTestServer(t *testing.T, stub imservice.Client, authenticator Authenticator) func(opts ...imclient.Option) *imclient.Client {
+	socket := filepath.Join(t.TempDir(), "http-server.sock")
+
+	useTestClient(t, stub)
+	h := server.New(server.WithNetwork("unix"), server.WithHostPorts(socket), server.WithDisablePrintRoute(true))
+	h.Use(RequestIDMiddleware)
+	var middleware []app.HandlerFunc
+	if authenticator != nil {
+		middleware = append(middleware, AuthMiddleware(authenticator))
+	}
+	registerAPIRoutes(h.Group("/api", middleware...))
+	go h.Run()
+
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, "unix", socket)
+	}
+	transport := &http.Transport{DialContext: dial}
+	httpClient := &http.Client{Transport: transport}
+
+	// Shutting down waits for the requests in flight, which use cli, before it
+	// is restored
+	t.Cleanup(func() {
+		transport.CloseIdleConnections()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		h.Shutdown(ctx)
+	})
+	newClient := func(opts ...imclient.Option) *imclient.Client {
+		return imclient.New("http://http-server", append([]imclient.Option{imclient.WithHTTPClient(httpClient)}, opts...)...)
+	}
+
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		if conn, err := net.Dial("unix", socket); err == nil {
+			conn.Close()
+			return newClient
+		}
+	}
+	t.Fatalf("Server did not start listening on %s\n", socket)
+	return nil
+}
+
+func TestClient_SendAndMessages(t *testing.T) {
+	client := startTestServer(t, &stubIMClient{}, nil)()
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		err := client.Send(ctx, &imclient.SendRequest{Chat: "a:b", Text: fmt.Sprint(i), Sender: "a"})
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, client.Send(ctx, &imclient.SendRequest{Chat: "a:c", Text: "other chat", Sender: "a"}))
+
+	resp, err := client.Pull(ctx, &imclient.PullRequest{Chat: "a:b", Limit: 2})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Messages, 2)
+	assert.True(t, resp.HasMore)
+	assert.Equal(t, int64(2), resp.NextCursor)
+
+	var texts []string
+	messages := client.Messages(ctx, imclient.PullRequest{Chat: "a:b", Limit: 2})
+	for messages.Next() {
+		assert.Equal(t, "a", messages.Message().Sender)
+		texts = append(texts, messages.Message().Text)
+	}
+	assert.Nil(t, messages.Err())
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, texts)
+	assert.Equal(t, int64(5), messages.Cursor())
+
+	chats, err := client.ListChats(ctx, &imclient.ListChatsRequest{User: "a"})
+	assert.Nil(t, err)
+	assert.False(t, chats.HasMore)
+	if assert.Len(t, chats.Chats, 2) {
+		assert.Equal(t, "a:c", chats.Chats[0].Chat)
+		assert.Equal(t, "a:b", chats.Chats[1].Chat)
+		assert.Equal(t, int64(5), chats.Chats[1].MessageCount)
+	}
+
+	texts = nil
+	messages = client.Messages(ctx, imclient.PullRequest{Chat: "a:b", Limit: 2, Reverse: true})
+	for messages.Next() {
+		texts = append(texts, messages.Message().Text)
+	}
+	assert.Nil(t, messages.Err())
+	assert.Equal(t, []string{"4", "3", "2", "1", "0"}, texts)
+}
+
+func TestClient_ExportMessages(t *testing.T) {
+	client := startTestServer(t, &stubIMClient{}, nil)()
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		assert.Nil(t, client.Send(ctx, &imclient.SendRequest{Chat: "a:b", Text: fmt.Sprint(i), Sender: "a"}))
+	}
+
+	resp, err := client.Export(ctx, &imclient.ExportRequest{Chat: "a:b", Limit: 2})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Messages, 2)
+	assert.True(t, resp.HasMore)
+	assert.Equal(t, "2", resp.NextCursor)
+
+	var texts []string
+	messages := client.ExportMessages(ctx, imclient.ExportRequest{Chat: "a:b", Limit: 2, Reverse: true})
+	for messages.Next() {
+		texts = append(texts, messages.Message().Text)
+	}
+	assert.Nil(t, messages.Err())
+	assert.Equal(t, []string{"4", "3", "2", "1", "0"}, texts)
+
+	_, err = client.Export(ctx, &imclient.ExportRequest{Chat: "a:b", Cursor: "x"})
+	var apiErr *imclient.Error
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, imclient.CodeInvalidCursor, apiErr.Code)
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	newClient := startTestServer(t, &stubIMClient{}, NewAPIKeyAuthenticator(map[string]string{"secret": "a"}))
+	ctx := context.Background()
+
+	var apiErr *imclient.Error
+	err := newClient().Send(ctx, &imclient.SendRequest{Chat: "a:b", Text: "hi"})
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.Equal(t, imclient.CodeUnauthenticated, apiErr.Code)
+	assert.NotEmpty(t, apiErr.RequestID)
+
+	client := newClient(imclient.WithAPIKey("secret"))
+	assert.Nil(t, client.Send(ctx, &imclient.SendRequest{Chat: "a:b", Text: "hi"}))
+
+	err = client.Send(ctx, &imclient.SendRequest{Chat: "a:b", Text: "hi", Sender: "b"})
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	assert.Equal(t, imclient.CodePermissionDenied, apiErr.Code)
+
+	err = client.Send(ctx, &imclient.SendRequest{Chat: "a:b"})
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, imclient.CodeInvalidArgument, apiErr.Code)
+	assert.Equal(t, "text is empty", apiErr.Message)
+
+	messages := client.Messages(ctx, imclient.PullRequest{Chat: "b:c"})
+	assert.False(t, messages.Next())
+	assert.True(t, errors.As(messages.Err(), &apiErr))
+	assert.Equal(t, imclient.CodePermissionDenied, apiErr.Code)
+}
+
+func TestClient_Retries(t *testing.T) {
+	stub := &stubIMClient{
+		messages:   []*rpc.Message{{Chat: "a:b", Text: "hi", Sender: "a"}},
+		pullErrors: []error{kerrors.ErrRPCTimeout, errors.New("failed")},
+	}
+	newClient := startTestServer(t, stub, nil)
+	ctx := context.Background()
+
+	// Unavailable, then an internal error which is not retried
+	_, err := newClient(imclient.WithRetries(3, time.Millisecond)).Pull(ctx, &imclient.PullRequest{Chat: "a:b"})
+	var apiErr *imclient.Error
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, imclient.CodeInternal, apiErr.Code)
+	assert.Equal(t, 2, stub.pullCount())
+
+	stub.failPulls(kerrors.ErrRPCTimeout)
+	resp, err := newClient(imclient.WithRetries(1, time.Millisecond)).Pull(ctx, &imclient.PullRequest{Chat: "a:b"})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Messages, 1)
+	assert.Equal(t, 2, stub.pullCount())
+
+	stub.failPulls(kerrors.ErrRPCTimeout)
+	_, err = newClient(imclient.WithRetries(0, 0)).Pull(ctx, &imclient.PullRequest{Chat: "a:b"})
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Equal(t, time.Second, apiErr.RetryAfter)
+	assert.Equal(t, 1, stub.pullCount())
+
+	// Retries wait no longer than the context allows
+	stub.failPulls(kerrors.ErrRPCTimeout)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = newClient().Pull(timeoutCtx, &imclient.PullRequest{Chat: "a:b"})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, 1, stub.pullCount())
+
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	err = newClient().Send(canceledCtx, &imclient.SendRequest{Chat: "a:b", Text: "hi"})
+	assert.True(t, errors.Is(err, context.Canceled))
+}
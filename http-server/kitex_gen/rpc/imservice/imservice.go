@@ -19,8 +19,22 @@ func NewServiceInfo() *kitex.ServiceInfo {
 	serviceName := "IMService"
 	handlerType := (*rpc.IMService)(nil)
 	methods := map[string]kitex.MethodInfo{
-		"Send": kitex.NewMethodInfo(sendHandler, newIMServiceSendArgs, newIMServiceSendResult, false),
-		"Pull": kitex.NewMethodInfo(pullHandler, newIMServicePullArgs, newIMServicePullResult, false),
+		"Send":           kitex.NewMethodInfo(sendHandler, newIMServiceSendArgs, newIMServiceSendResult, false),
+		"Pull":           kitex.NewMethodInfo(pullHandler, newIMServicePullArgs, newIMServicePullResult, false),
+		"Block":          kitex.NewMethodInfo(blockHandler, newIMServiceBlockArgs, newIMServiceBlockResult, false),
+		"Unblock":        kitex.NewMethodInfo(unblockHandler, newIMServiceUnblockArgs, newIMServiceUnblockResult, false),
+		"ListBlocked":    kitex.NewMethodInfo(listBlockedHandler, newIMServiceListBlockedArgs, newIMServiceListBlockedResult, false),
+		"QueryAuditLog":  kitex.NewMethodInfo(queryAuditLogHandler, newIMServiceQueryAuditLogArgs, newIMServiceQueryAuditLogResult, false),
+		"PublishKey":     kitex.NewMethodInfo(publishKeyHandler, newIMServicePublishKeyArgs, newIMServicePublishKeyResult, false),
+		"GetKeys":        kitex.NewMethodInfo(getKeysHandler, newIMServiceGetKeysArgs, newIMServiceGetKeysResult, false),
+		"BatchSend":      kitex.NewMethodInfo(batchSendHandler, newIMServiceBatchSendArgs, newIMServiceBatchSendResult, false),
+		"MultiPull":      kitex.NewMethodInfo(multiPullHandler, newIMServiceMultiPullArgs, newIMServiceMultiPullResult, false),
+		"ListChats":      kitex.NewMethodInfo(listChatsHandler, newIMServiceListChatsArgs, newIMServiceListChatsResult, false),
+		"ExportMessages": kitex.NewMethodInfo(exportMessagesHandler, newIMServiceExportMessagesArgs, newIMServiceExportMessagesResult, false),
+		"ImportMessages": kitex.NewMethodInfo(importMessagesHandler, newIMServiceImportMessagesArgs, newIMServiceImportMessagesResult, false),
+		"EraseUser":      kitex.NewMethodInfo(eraseUserHandler, newIMServiceEraseUserArgs, newIMServiceEraseUserResult, false),
+		"ListFlags":      kitex.NewMethodInfo(listFlagsHandler, newIMServiceListFlagsArgs, newIMServiceListFlagsResult, false),
+		"ReviewFlag":     kitex.NewMethodInfo(reviewFlagHandler, newIMServiceReviewFlagArgs, newIMServiceReviewFlagResult, false),
 	}
 	extra := map[string]interface{}{
 		"PackageName": "rpc",
@@ -72,6 +86,258 @@ func newIMServicePullResult() interface{} {
 	return rpc.NewIMServicePullResult()
 }
 
+func blockHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*rpc.IMServiceBlockArgs)
+	realResult := result.(*rpc.IMServiceBlockResult)
+	success, err := handler.(rpc.IMService).Block(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newIMServiceBlockArgs() interface{} {
+	return rpc.NewIMServiceBlockArgs()
+}
+
+func newIMServiceBlockResult() interface{} {
+	return rpc.NewIMServiceBlockResult()
+}
+
+func unblockHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*rpc.IMServiceUnblockArgs)
+	realResult := result.(*rpc.IMServiceUnblockResult)
+	success, err := handler.(rpc.IMService).Unblock(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newIMServiceUnblockArgs() interface{} {
+	return rpc.NewIMServiceUnblockArgs()
+}
+
+func newIMServiceUnblockResult() interface{} {
+	return rpc.NewIMServiceUnblockResult()
+}
+
+func listBlockedHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*rpc.IMServiceListBlockedArgs)
+	realResult := result.(*rpc.IMServiceListBlockedResult)
+	success, err := handler.(rpc.IMService).ListBlocked(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newIMServiceListBlockedArgs() interface{} {
+	return rpc.NewIMServiceListBlockedArgs()
+}
+
+func newIMServiceListBlockedResult() interface{} {
+	return rpc.NewIMServiceListBlockedResult()
+}
+
+func queryAuditLogHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*rpc.IMServiceQueryAuditLogArgs)
+	realResult := result.(*rpc.IMServiceQueryAuditLogResult)
+	success, err := handler.(rpc.IMService).QueryAuditLog(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newIMServiceQueryAuditLogArgs() interface{} {
+	return rpc.NewIMServiceQueryAuditLogArgs()
+}
+
+func newIMServiceQueryAuditLogResult() interface{} {
+	return rpc.NewIMServiceQueryAuditLogResult()
+}
+
+func publishKeyHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*rpc.IMServicePublishKeyArgs)
+	realResult := result.(*rpc.IMServicePublishKeyResult)
+	success, err := handler.(rpc.IMService).PublishKey(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newIMServicePublishKeyArgs() interface{} {
+	return rpc.NewIMServicePublishKeyArgs()
+}
+
+func newIMServicePublishKeyResult() interface{} {
+	return rpc.NewIMServicePublishKeyResult()
+}
+
+func getKeysHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*rpc.IMServiceGetKeysArgs)
+	realResult := result.(*rpc.IMServiceGetKeysResult)
+	success, err := handler.(rpc.IMService).GetKeys(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newIMServiceGetKeysArgs() interface{} {
+	return rpc.NewIMServiceGetKeysArgs()
+}
+
+func newIMServiceGetKeysResult() interface{} {
+	return rpc.NewIMServiceGetKeysResult()
+}
+
+func batchSendHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*rpc.IMServiceBatchSendArgs)
+	realResult := result.(*rpc.IMServiceBatchSendResult)
+	success, err := handler.(rpc.IMService).BatchSend(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newIMServiceBatchSendArgs() interface{} {
+	return rpc.NewIMServiceBatchSendArgs()
+}
+
+func newIMServiceBatchSendResult() interface{} {
+	return rpc.NewIMServiceBatchSendResult()
+}
+
+func multiPullHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*rpc.IMServiceMultiPullArgs)
+	realResult := result.(*rpc.IMServiceMultiPullResult)
+	success, err := handler.(rpc.IMService).MultiPull(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newIMServiceMultiPullArgs() interface{} {
+	return rpc.NewIMServiceMultiPullArgs()
+}
+
+func newIMServiceMultiPullResult() interface{} {
+	return rpc.NewIMServiceMultiPullResult()
+}
+
+func listChatsHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*rpc.IMServiceListChatsArgs)
+	realResult := result.(*rpc.IMServiceListChatsResult)
+	success, err := handler.(rpc.IMService).ListChats(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newIMServiceListChatsArgs() interface{} {
+	return rpc.NewIMServiceListChatsArgs()
+}
+
+func newIMServiceListChatsResult() interface{} {
+	return rpc.NewIMServiceListChatsResult()
+}
+
+func exportMessagesHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*rpc.IMServiceExportMessagesArgs)
+	realResult := result.(*rpc.IMServiceExportMessagesResult)
+	success, err := handler.(rpc.IMService).ExportMessages(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newIMServiceExportMessagesArgs() interface{} {
+	return rpc.NewIMServiceExportMessagesArgs()
+}
+
+func newIMServiceExportMessagesResult() interface{} {
+	return rpc.NewIMServiceExportMessagesResult()
+}
+
+func importMessagesHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*rpc.IMServiceImportMessagesArgs)
+	realResult := result.(*rpc.IMServiceImportMessagesResult)
+	success, err := handler.(rpc.IMService).ImportMessages(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newIMServiceImportMessagesArgs() interface{} {
+	return rpc.NewIMServiceImportMessagesArgs()
+}
+
+func newIMServiceImportMessagesResult() interface{} {
+	return rpc.NewIMServiceImportMessagesResult()
+}
+
+func eraseUserHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*rpc.IMServiceEraseUserArgs)
+	realResult := result.(*rpc.IMServiceEraseUserResult)
+	success, err := handler.(rpc.IMService).EraseUser(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newIMServiceEraseUserArgs() interface{} {
+	return rpc.NewIMServiceEraseUserArgs()
+}
+
+func newIMServiceEraseUserResult() interface{} {
+	return rpc.NewIMServiceEraseUserResult()
+}
+
+func listFlagsHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*rpc.IMServiceListFlagsArgs)
+	realResult := result.(*rpc.IMServiceListFlagsResult)
+	success, err := handler.(rpc.IMService).ListFlags(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newIMServiceListFlagsArgs() interface{} {
+	return rpc.NewIMServiceListFlagsArgs()
+}
+
+func newIMServiceListFlagsResult() interface{} {
+	return rpc.NewIMServiceListFlagsResult()
+}
+
+func reviewFlagHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*rpc.IMServiceReviewFlagArgs)
+	realResult := result.(*rpc.IMServiceReviewFlagResult)
+	success, err := handler.(rpc.IMService).ReviewFlag(ctx, realArg.Req)
+	if err != nil {
+		return err
+	}
+	realResult.Success = success
+	return nil
+}
+func newIMServiceReviewFlagArgs() interface{} {
+	return rpc.NewIMServiceReviewFlagArgs()
+}
+
+func newIMServiceReviewFlagResult() interface{} {
+	return rpc.NewIMServiceReviewFlagResult()
+}
+
 type kClient struct {
 	c client.Client
 }
@@ -101,3 +367,143 @@ func (p *kClient) Pull(ctx context.Context, req *rpc.PullRequest) (r *rpc.PullRe
 	}
 	return _result.GetSuccess(), nil
 }
+
+func (p *kClient) Block(ctx context.Context, req *rpc.BlockRequest) (r *rpc.BlockResponse, err error) {
+	var _args rpc.IMServiceBlockArgs
+	_args.Req = req
+	var _result rpc.IMServiceBlockResult
+	if err = p.c.Call(ctx, "Block", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Unblock(ctx context.Context, req *rpc.UnblockRequest) (r *rpc.UnblockResponse, err error) {
+	var _args rpc.IMServiceUnblockArgs
+	_args.Req = req
+	var _result rpc.IMServiceUnblockResult
+	if err = p.c.Call(ctx, "Unblock", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ListBlocked(ctx context.Context, req *rpc.ListBlockedRequest) (r *rpc.ListBlockedResponse, err error) {
+	var _args rpc.IMServiceListBlockedArgs
+	_args.Req = req
+	var _result rpc.IMServiceListBlockedResult
+	if err = p.c.Call(ctx, "ListBlocked", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) QueryAuditLog(ctx context.Context, req *rpc.QueryAuditLogRequest) (r *rpc.QueryAuditLogResponse, err error) {
+	var _args rpc.IMServiceQueryAuditLogArgs
+	_args.Req = req
+	var _result rpc.IMServiceQueryAuditLogResult
+	if err = p.c.Call(ctx, "QueryAuditLog", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) PublishKey(ctx context.Context, req *rpc.PublishKeyRequest) (r *rpc.PublishKeyResponse, err error) {
+	var _args rpc.IMServicePublishKeyArgs
+	_args.Req = req
+	var _result rpc.IMServicePublishKeyResult
+	if err = p.c.Call(ctx, "PublishKey", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) GetKeys(ctx context.Context, req *rpc.GetKeysRequest) (r *rpc.GetKeysResponse, err error) {
+	var _args rpc.IMServiceGetKeysArgs
+	_args.Req = req
+	var _result rpc.IMServiceGetKeysResult
+	if err = p.c.Call(ctx, "GetKeys", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) BatchSend(ctx context.Context, req *rpc.BatchSendRequest) (r *rpc.BatchSendResponse, err error) {
+	var _args rpc.IMServiceBatchSendArgs
+	_args.Req = req
+	var _result rpc.IMServiceBatchSendResult
+	if err = p.c.Call(ctx, "BatchSend", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) MultiPull(ctx context.Context, req *rpc.MultiPullRequest) (r *rpc.MultiPullResponse, err error) {
+	var _args rpc.IMServiceMultiPullArgs
+	_args.Req = req
+	var _result rpc.IMServiceMultiPullResult
+	if err = p.c.Call(ctx, "MultiPull", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ListChats(ctx context.Context, req *rpc.ListChatsRequest) (r *rpc.ListChatsResponse, err error) {
+	var _args rpc.IMServiceListChatsArgs
+	_args.Req = req
+	var _result rpc.IMServiceListChatsResult
+	if err = p.c.Call(ctx, "ListChats", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ExportMessages(ctx context.Context, req *rpc.ExportMessagesRequest) (r *rpc.ExportMessagesResponse, err error) {
+	var _args rpc.IMServiceExportMessagesArgs
+	_args.Req = req
+	var _result rpc.IMServiceExportMessagesResult
+	if err = p.c.Call(ctx, "ExportMessages", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ImportMessages(ctx context.Context, req *rpc.ImportMessagesRequest) (r *rpc.ImportMessagesResponse, err error) {
+	var _args rpc.IMServiceImportMessagesArgs
+	_args.Req = req
+	var _result rpc.IMServiceImportMessagesResult
+	if err = p.c.Call(ctx, "ImportMessages", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) EraseUser(ctx context.Context, req *rpc.EraseUserRequest) (r *rpc.EraseUserResponse, err error) {
+	var _args rpc.IMServiceEraseUserArgs
+	_args.Req = req
+	var _result rpc.IMServiceEraseUserResult
+	if err = p.c.Call(ctx, "EraseUser", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ListFlags(ctx context.Context, req *rpc.ListFlagsRequest) (r *rpc.ListFlagsResponse, err error) {
+	var _args rpc.IMServiceListFlagsArgs
+	_args.Req = req
+	var _result rpc.IMServiceListFlagsResult
+	if err = p.c.Call(ctx, "ListFlags", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ReviewFlag(ctx context.Context, req *rpc.ReviewFlagRequest) (r *rpc.ReviewFlagResponse, err error) {
+	var _args rpc.IMServiceReviewFlagArgs
+	_args.Req = req
+	var _result rpc.IMServiceReviewFlagResult
+	if err = p.c.Call(ctx, "ReviewFlag", &_args, &_result); err != nil {
+		return
+	}
+	return _result.GetSuccess(), nil
+}
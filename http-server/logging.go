@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+	"time"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/common/logging"
+	"github.com/bytedance/gopkg/cloud/metainfo"
+	"github.com/cloudwego/hertz/pkg/app"
+	"golang.org/x/exp/slog"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestIDMiddleware assigns every request an ID, reusing a well-formed
+// X-Request-ID sent by the client, returns it in the X-Request-ID response header
+// and adds it to the context, from which it is sent along with every RPC.
+func RequestIDMiddleware(ctx context.Context, c *app.RequestContext) {
+	requestID := string(c.GetHeader(requestIDHeader))
+	if !requestIDPattern.MatchString(requestID) {
+		requestID = newRequestID()
+	}
+
+	c.Header(requestIDHeader, requestID)
+	c.Next(metainfo.WithValue(ctx, logging.RequestIDMetaKey, requestID))
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(id)
+}
+
+// HTTPLoggingMiddleware logs the method, route, status and latency of every
+// HTTP request.
+func HTTPLoggingMiddleware(ctx context.Context, c *app.RequestContext) {
+	start := time.Now()
+	c.Next(ctx)
+
+	slog.InfoCtx(ctx, "http request handled",
+		slog.String("method", string(c.Method())),
+		slog.String("path", string(c.Path())),
+		slog.String("route", c.FullPath()),
+		slog.Int("status", c.Response.StatusCode()),
+		slog.Duration("duration", time.Since(start)),
+	)
+}
@@ -2,9 +2,17 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"strconv"
 	"time"
 
+	"github.com/TikTokTechImmersion/assignment_demo_2023/common/configload"
+	"github.com/TikTokTechImmersion/assignment_demo_2023/common/logging"
+	"github.com/TikTokTechImmersion/assignment_demo_2023/common/tracing"
 	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/kitex_gen/rpc"
 	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/kitex_gen/rpc/imservice"
 	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/proto_gen/api"
@@ -12,13 +20,61 @@ import (
 	"github.com/cloudwego/hertz/pkg/app/server"
 	"github.com/cloudwego/hertz/pkg/common/utils"
 	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/hertz/pkg/route"
 	"github.com/cloudwego/kitex/client"
 	"github.com/cloudwego/kitex/pkg/loadbalance"
-	etcd "github.com/kitex-contrib/registry-etcd"
+	"github.com/cloudwego/kitex/transport"
+	"golang.org/x/exp/slog"
 )
 
 var cli imservice.Client
 
+type QueryAuditLogResponseRest struct {
+	Entries    []*api.AuditEntry `json:"entries"`
+	HasMore    bool              `json:"has_more"`
+	NextCursor int64             `json:"next_cursor,omitempty"`
+}
+
+type ListFlagsResponseRest struct {
+	Flags      []*api.ModerationFlag `json:"flags"`
+	HasMore    bool                  `json:"has_more"`
+	NextCursor int64                 `json:"next_cursor,omitempty"`
+}
+
+type SendStatusRest struct {
+	Code       int32  `json:"code"`
+	Message    string `json:"message,omitempty"`
+	RetryAfter int64  `json:"retry_after,omitempty"`
+}
+
+type BatchSendResponseRest struct {
+	Statuses []*SendStatusRest `json:"statuses"`
+}
+
+type ChatPageRest struct {
+	Chat       string         `json:"chat"`
+	Messages   []*api.Message `json:"messages"`
+	HasMore    bool           `json:"has_more"`
+	NextCursor int64          `json:"next_cursor,omitempty"`
+	Error      *api.Error     `json:"error,omitempty"`
+}
+
+type MultiPullResponseRest struct {
+	Chats []*ChatPageRest `json:"chats"`
+}
+
+type ListChatsResponseRest struct {
+	Chats      []*api.ChatSummary `json:"chats"`
+	HasMore    bool               `json:"has_more"`
+	NextCursor int64              `json:"next_cursor,omitempty"`
+}
+
+type ExportMessagesResponseRest struct {
+	Messages   []*api.Message `json:"messages"`
+	HasMore    bool           `json:"has_more"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
 type PullResponseRest struct {
 	Messages   []*api.Message `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages"`
 	HasMore    bool           `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more"`                    // if true, can use next_cursor to pull the next page of messages
@@ -26,50 +82,153 @@ type PullResponseRest struct {
 }
 
 func main() {
-	r, err := etcd.NewEtcdResolver([]string{"etcd:2379"})
+	cfg := DefaultConfig()
+	printed, err := configload.Load(cfg, os.Args, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	} else if printed {
+		return
+	}
+
+	logging.Init(cfg.Logging.Level, cfg.Logging.Format)
+
+	shutdownTracing, err := tracing.Init(cfg.Server.ServiceName, cfg.Tracing.Exporter)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer shutdownTracing(context.Background())
+
+	r, err := NewResolverFromConfig(cfg.Discovery)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	cli = imservice.MustNewClient("demo.rpc.server",
-		client.WithResolver(r),
-		client.WithRPCTimeout(1*time.Second),
+	resolver := &InstrumentedResolver{r}
+	opts := []client.Option{
+		client.WithResolver(resolver),
+		// Trace context and caller metadata are carried in TTHeader, which plain
+		// framed thrift lacks.
+		client.WithTransportProtocol(transport.TTHeader),
+		client.WithMiddleware(RPCTracingMiddleware),
+		client.WithMiddleware(RPCMetricsMiddleware),
+		client.WithRPCTimeout(cfg.RPC.Timeout),
 		client.WithLoadBalancer(loadbalance.NewWeightedRandomBalancer()),
-	)
+	}
+	opts = append(opts, NewResilienceOptions(cfg.RPC)...)
+
+	if mw := NewCallerSignMiddlewareFromConfig(cfg.S2S, cfg.Server.ServiceName); mw != nil {
+		opts = append(opts, client.WithMiddleware(mw))
+	} else {
+		slog.Warn("s2s.shared_secret not set, RPC calls will not be signed")
+	}
 
-	h := server.Default(server.WithHostPorts("0.0.0.0:8080"))
+	cli = imservice.MustNewClient(cfg.RPC.ServiceName, opts...)
+
+	shutdown := cfg.Shutdown
+	h := server.Default(server.WithHostPorts(cfg.Server.Addr), server.WithExitWaitTime(shutdown.Timeout))
+	h.SetCustomSignalWaiter(shutdown.SignalWaiter)
+	h.Use(RequestIDMiddleware, CORSMiddleware(cfg.Server.CORSOrigins), HTTPTracingMiddleware, HTTPLoggingMiddleware, HTTPMetricsMiddleware)
+
+	h.GET("/metrics", MetricsHandler())
+
+	h.GET("/healthz", HealthzHandler)
+	h.GET("/readyz", ReadyzHandler(resolver, cfg.RPC.ServiceName))
+
+	h.GET("/openapi.json", OpenAPIHandler)
+	h.GET("/docs", DocsHandler)
 
 	h.GET("/ping", func(c context.Context, ctx *app.RequestContext) {
 		ctx.JSON(consts.StatusOK, utils.H{"message": "pong"})
 	})
 
-	h.POST("/api/send", sendMessage)
-	h.GET("/api/pull", pullMessage)
+	var middleware []app.HandlerFunc
+	authenticator := NewAuthenticatorFromConfig(cfg.Auth)
+	if authenticator != nil {
+		middleware = append(middleware, AuthMiddleware(authenticator))
+	} else {
+		slog.Warn("no authenticators configured, HTTP API authentication is disabled")
+	}
+
+	registerAPIRoutes(h.Group("/api", middleware...))
+
+	if cfg.Server.GRPCAddr != "" {
+		grpcServer := NewGRPCServer(authenticator)
+		httpServer := &http.Server{Addr: cfg.Server.GRPCAddr, Handler: NewGRPCHandler(grpcServer, cfg.Server.CORSOrigins)}
+		go func() {
+			slog.Info("serving gRPC and gRPC-Web", "addr", cfg.Server.GRPCAddr)
+			if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatal(err)
+			}
+		}()
+		// Shut down along with the Hertz server, waiting for in-flight calls
+		// within the same timeout
+		h.OnShutdown = append(h.OnShutdown, func(ctx context.Context) {
+			httpServer.Shutdown(ctx)
+			stopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				grpcServer.Stop()
+			}
+		})
+	}
 
+	// Spin returns once in-flight requests have drained, after which the
+	// deferred functions flush traces.
 	h.Spin()
+	slog.Info("server stopped")
+}
+
+// registerAPIRoutes registers the handlers of the HTTP API, which must match
+// the paths in idl_http.proto that openapi.json is generated from.
+func registerAPIRoutes(api *route.RouterGroup) {
+	api.POST("/send", sendMessage)
+	api.GET("/pull", pullMessage)
+	api.POST("/send/batch", batchSend)
+	api.GET("/pull/multi", multiPull)
+	api.GET("/chats", listChats)
+	api.GET("/export", exportMessages)
+	api.POST("/block", blockUser)
+	api.POST("/unblock", unblockUser)
+	api.GET("/blocked", listBlocked)
+	api.GET("/admin/audit", queryAuditLog)
+	api.GET("/admin/flags", listFlags)
+	api.POST("/admin/flags/review", reviewFlag)
+	api.POST("/keys", publishKey)
+	api.GET("/keys", getKeys)
 }
 
 func sendMessage(ctx context.Context, c *app.RequestContext) {
 	var req api.SendRequest
 	err := c.Bind(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, "Failed to parse request body: %v", err)
+		writeError(ctx, c, rpc.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("failed to parse request body: %v", err))
+		return
+	}
+
+	if !authorizeUser(c, &req.Sender) {
+		writeError(ctx, c, rpc.ErrorCode_PERMISSION_DENIED, "sender does not match authenticated identity")
 		return
 	}
+
 	resp, err := cli.Send(ctx, &rpc.SendRequest{
-		Message: &rpc.Message{
-			Chat:     req.Chat,
-			Text:     req.Text,
-			Sender:   req.Sender,
-			SendTime: time.Now().UTC().UnixMicro(),
-		},
+		Message: newRPCMessage(&req, time.Now().UTC().UnixMicro()),
 	})
 	if err != nil {
-		c.String(consts.StatusInternalServerError, err.Error())
+		rpcError(ctx, c, "Send", err)
 	} else if resp.Code != 0 {
-		c.String(consts.StatusInternalServerError, resp.Msg)
+		if resp.Code == int32(rpc.ErrorCode_RATE_LIMITED) {
+			// Retry-After is in whole seconds, round up so clients do not retry too early
+			c.Header("Retry-After", strconv.FormatInt((resp.GetRetryAfter()+999)/1000, 10))
+		}
+		responseError(ctx, c, resp)
 	} else {
-		c.Status(consts.StatusOK)
+		render(c, consts.StatusOK, &api.SendResponse{}, nil)
 	}
 }
 
@@ -77,7 +236,12 @@ func pullMessage(ctx context.Context, c *app.RequestContext) {
 	var req api.PullRequest
 	err := c.Bind(&req)
 	if err != nil {
-		c.String(consts.StatusBadRequest, "Failed to parse request body: %v", err)
+		writeError(ctx, c, rpc.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("failed to parse request body: %v", err))
+		return
+	}
+
+	if principal := GetPrincipal(c); principal != nil && !IsChatMember(req.Chat, principal.ID) {
+		writeError(ctx, c, rpc.ErrorCode_PERMISSION_DENIED, "not a member of chat")
 		return
 	}
 
@@ -88,24 +252,614 @@ func pullMessage(ctx context.Context, c *app.RequestContext) {
 		Reverse: &req.Reverse,
 	})
 	if err != nil {
-		c.String(consts.StatusInternalServerError, err.Error())
+		rpcError(ctx, c, "Pull", err)
+		return
+	} else if resp.Code != 0 {
+		responseError(ctx, c, resp)
+		return
+	}
+	pullResp := &api.PullResponse{
+		Messages:   newAPIMessages(resp.Messages),
+		HasMore:    resp.GetHasMore(),
+		NextCursor: resp.GetNextCursor(),
+	}
+	render(c, consts.StatusOK, pullResp, &PullResponseRest{
+		Messages:   pullResp.Messages,
+		HasMore:    pullResp.HasMore,
+		NextCursor: pullResp.NextCursor,
+	})
+}
+
+func batchSend(ctx context.Context, c *app.RequestContext) {
+	var req api.BatchSendRequest
+	err := c.Bind(&req)
+	if err != nil {
+		writeError(ctx, c, rpc.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("failed to parse request body: %v", err))
+		return
+	}
+
+	// Messages are a microsecond apart so that they keep the order they were sent in
+	sendTime := time.Now().UTC().UnixMicro()
+	messages := make([]*rpc.Message, 0, len(req.Messages))
+	for i, message := range req.Messages {
+		if message == nil {
+			message = new(api.SendRequest)
+		}
+		if !authorizeUser(c, &message.Sender) {
+			writeError(ctx, c, rpc.ErrorCode_PERMISSION_DENIED, fmt.Sprintf("sender of message %d does not match authenticated identity", i))
+			return
+		}
+		messages = append(messages, newRPCMessage(message, sendTime+int64(i)))
+	}
+
+	resp, err := cli.BatchSend(ctx, &rpc.BatchSendRequest{
+		Messages: messages,
+	})
+	if err != nil {
+		rpcError(ctx, c, "BatchSend", err)
 		return
 	} else if resp.Code != 0 {
-		c.String(consts.StatusInternalServerError, resp.Msg)
+		responseError(ctx, c, resp)
+		return
+	}
+	statuses := newAPISendStatuses(resp.Statuses)
+	restStatuses := make([]*SendStatusRest, 0, len(statuses))
+	for _, status := range statuses {
+		restStatuses = append(restStatuses, &SendStatusRest{
+			Code:       status.Code,
+			Message:    status.Message,
+			RetryAfter: status.RetryAfter,
+		})
+	}
+	render(c, consts.StatusOK, &api.BatchSendResponse{Statuses: statuses}, &BatchSendResponseRest{Statuses: restStatuses})
+}
+
+func multiPull(ctx context.Context, c *app.RequestContext) {
+	var req api.MultiPullRequest
+	err := c.Bind(&req)
+	if err != nil {
+		writeError(ctx, c, rpc.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("failed to parse request body: %v", err))
 		return
 	}
-	messages := make([]*api.Message, 0, len(resp.Messages))
-	for _, msg := range resp.Messages {
+
+	resp, err := cli.MultiPull(ctx, &rpc.MultiPullRequest{
+		Chats:   req.Chats,
+		Limit:   req.Limit,
+		Reverse: &req.Reverse,
+	})
+	if err != nil {
+		rpcError(ctx, c, "MultiPull", err)
+		return
+	} else if resp.Code != 0 {
+		responseError(ctx, c, resp)
+		return
+	}
+	denyChatPages(GetPrincipal(c), resp.Chats)
+	pages := newAPIChatPages(ctx, resp.Chats)
+	restPages := make([]*ChatPageRest, 0, len(pages))
+	for _, page := range pages {
+		restPages = append(restPages, &ChatPageRest{
+			Chat:       page.Chat,
+			Messages:   page.Messages,
+			HasMore:    page.HasMore,
+			NextCursor: page.NextCursor,
+			Error:      page.Error,
+		})
+	}
+	render(c, consts.StatusOK, &api.MultiPullResponse{Chats: pages}, &MultiPullResponseRest{Chats: restPages})
+}
+
+func listChats(ctx context.Context, c *app.RequestContext) {
+	var req api.ListChatsRequest
+	err := c.Bind(&req)
+	if err != nil {
+		writeError(ctx, c, rpc.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("failed to parse request body: %v", err))
+		return
+	}
+
+	if !authorizeUser(c, &req.User) {
+		writeError(ctx, c, rpc.ErrorCode_PERMISSION_DENIED, "user does not match authenticated identity")
+		return
+	}
+
+	resp, err := cli.ListChats(ctx, &rpc.ListChatsRequest{
+		User:   req.User,
+		Cursor: req.Cursor,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		rpcError(ctx, c, "ListChats", err)
+		return
+	} else if resp.Code != 0 {
+		responseError(ctx, c, resp)
+		return
+	}
+	listResp := &api.ListChatsResponse{
+		Chats:      newAPIChatSummaries(resp.Chats),
+		HasMore:    resp.GetHasMore(),
+		NextCursor: resp.GetNextCursor(),
+	}
+	render(c, consts.StatusOK, listResp, &ListChatsResponseRest{
+		Chats:      listResp.Chats,
+		HasMore:    listResp.HasMore,
+		NextCursor: listResp.NextCursor,
+	})
+}
+
+// newRPCMessage returns the message to send for a request to send one.
+func newRPCMessage(req *api.SendRequest, sendTime int64) *rpc.Message {
+	message := &rpc.Message{
+		Chat:     req.Chat,
+		Text:     req.Text,
+		Sender:   req.Sender,
+		SendTime: sendTime,
+	}
+	if req.Encrypted {
+		message.Encrypted = &req.Encrypted
+		message.SenderKeyId = &req.SenderKeyId
+		message.RecipientKeyId = &req.RecipientKeyId
+	}
+	return message
+}
+
+// newAPIMessages returns the messages pulled in the form they are responded with.
+func newAPIMessages(rpcMessages []*rpc.Message) []*api.Message {
+	messages := make([]*api.Message, 0, len(rpcMessages))
+	for _, msg := range rpcMessages {
 		messages = append(messages, &api.Message{
-			Chat:     msg.Chat,
-			Text:     msg.Text,
-			Sender:   msg.Sender,
-			SendTime: msg.SendTime,
+			Chat:           msg.Chat,
+			Text:           msg.Text,
+			Sender:         msg.Sender,
+			SendTime:       msg.SendTime,
+			Encrypted:      msg.GetEncrypted(),
+			SenderKeyId:    msg.GetSenderKeyId(),
+			RecipientKeyId: msg.GetRecipientKeyId(),
 		})
 	}
-	c.JSON(consts.StatusOK, &PullResponseRest{
-		Messages:   messages,
+	return messages
+}
+
+// newRPCExportMessagesRequest returns the export of a page of messages for a
+// request, leaving out the fields not set.
+func newRPCExportMessagesRequest(req *api.ExportMessagesRequest) *rpc.ExportMessagesRequest {
+	rpcReq := &rpc.ExportMessagesRequest{Limit: req.Limit}
+	if req.Chat != "" {
+		rpcReq.Chat = &req.Chat
+	}
+	if req.User != "" {
+		rpcReq.User = &req.User
+	}
+	if req.Cursor != "" {
+		rpcReq.Cursor = &req.Cursor
+	}
+	if req.Reverse {
+		rpcReq.Reverse = &req.Reverse
+	}
+	return rpcReq
+}
+
+// newAPISendStatuses returns the statuses of messages sent in a batch in the
+// form they are responded with.
+func newAPISendStatuses(rpcStatuses []*rpc.SendStatus) []*api.SendStatus {
+	statuses := make([]*api.SendStatus, 0, len(rpcStatuses))
+	for _, status := range rpcStatuses {
+		sendStatus := &api.SendStatus{Code: status.Code, RetryAfter: status.GetRetryAfter()}
+		if status.Code != 0 {
+			sendStatus.Message = status.Msg
+		}
+		statuses = append(statuses, sendStatus)
+	}
+	return statuses
+}
+
+// denyChatPages replaces the pages of the chats principal is not a member of
+// with PERMISSION_DENIED, as the rpc-server does when it knows the end user
+// from signed caller metadata. Pages which failed already, such as those of
+// invalid chats, are left as they are.
+func denyChatPages(principal *Principal, pages []*rpc.ChatPage) {
+	if principal == nil {
+		return
+	}
+	for i, page := range pages {
+		if page.Code == 0 && !IsChatMember(page.Chat, principal.ID) {
+			pages[i] = &rpc.ChatPage{Chat: page.Chat, Code: int32(rpc.ErrorCode_PERMISSION_DENIED), Msg: fmt.Sprintf("not a member of chat %s", page.Chat)}
+		}
+	}
+}
+
+// newAPIChatPages returns the pages of chats pulled together in the form they
+// are responded with.
+func newAPIChatPages(ctx context.Context, rpcPages []*rpc.ChatPage) []*api.ChatPage {
+	pages := make([]*api.ChatPage, 0, len(rpcPages))
+	for _, chat := range rpcPages {
+		page := &api.ChatPage{
+			Chat:       chat.Chat,
+			Messages:   newAPIMessages(chat.Messages),
+			HasMore:    chat.GetHasMore(),
+			NextCursor: chat.GetNextCursor(),
+		}
+		if chat.Code != 0 {
+			page.Error = &api.Error{Code: chat.Code, Message: chat.Msg, RequestId: logging.GetRequestID(ctx)}
+		}
+		pages = append(pages, page)
+	}
+	return pages
+}
+
+// newAPIChatSummaries returns the chats listed in the form they are responded
+// with.
+func newAPIChatSummaries(rpcChats []*rpc.ChatSummary) []*api.ChatSummary {
+	chats := make([]*api.ChatSummary, 0, len(rpcChats))
+	for _, chat := range rpcChats {
+		chats = append(chats, &api.ChatSummary{
+			Chat:         chat.Chat,
+			LastSendTime: chat.LastSendTime,
+			MessageCount: chat.MessageCount,
+		})
+	}
+	return chats
+}
+
+// newRPCQueryAuditLogRequest returns the query of the audit log for a request,
+// leaving out the filters not set.
+func newRPCQueryAuditLogRequest(req *api.QueryAuditLogRequest) *rpc.QueryAuditLogRequest {
+	rpcReq := &rpc.QueryAuditLogRequest{
+		Cursor: req.Cursor,
+		Limit:  req.Limit,
+	}
+	if req.Actor != "" {
+		rpcReq.Actor = &req.Actor
+	}
+	if req.Action != "" {
+		rpcReq.Action = &req.Action
+	}
+	if req.TargetChat != "" {
+		rpcReq.TargetChat = &req.TargetChat
+	}
+	if req.TargetUser != "" {
+		rpcReq.TargetUser = &req.TargetUser
+	}
+	if req.Since != 0 {
+		rpcReq.Since = &req.Since
+	}
+	if req.Until != 0 {
+		rpcReq.Until = &req.Until
+	}
+	return rpcReq
+}
+
+// newAPIAuditEntries returns the audit log entries queried in the form they
+// are responded with.
+func newAPIAuditEntries(rpcEntries []*rpc.AuditEntry) []*api.AuditEntry {
+	entries := make([]*api.AuditEntry, 0, len(rpcEntries))
+	for _, entry := range rpcEntries {
+		entries = append(entries, &api.AuditEntry{
+			Id:            entry.Id,
+			Action:        entry.Action,
+			Actor:         entry.Actor,
+			ActorService:  entry.ActorService,
+			TargetChat:    entry.TargetChat,
+			TargetMessage: entry.TargetMessage,
+			TargetUser:    entry.TargetUser,
+			Details:       entry.Details,
+			CreatedAt:     entry.CreatedAt,
+		})
+	}
+	return entries
+}
+
+// newRPCListFlagsRequest returns the listing of moderation flags for a request,
+// leaving out the filters not set.
+func newRPCListFlagsRequest(req *api.ListFlagsRequest) *rpc.ListFlagsRequest {
+	rpcReq := &rpc.ListFlagsRequest{
+		Cursor: req.Cursor,
+		Limit:  req.Limit,
+	}
+	if req.Chat != "" {
+		rpcReq.Chat = &req.Chat
+	}
+	if req.IncludeReviewed {
+		rpcReq.IncludeReviewed = &req.IncludeReviewed
+	}
+	return rpcReq
+}
+
+// newAPIModerationFlags returns the moderation flags listed in the form they
+// are responded with.
+func newAPIModerationFlags(rpcFlags []*rpc.ModerationFlag) []*api.ModerationFlag {
+	flags := make([]*api.ModerationFlag, 0, len(rpcFlags))
+	for _, flag := range rpcFlags {
+		flags = append(flags, &api.ModerationFlag{
+			Id:       flag.Id,
+			Chat:     flag.Chat,
+			Sender:   flag.Sender,
+			Text:     flag.Text,
+			SendTime: flag.SendTime,
+			Filter:   flag.Filter,
+			Reason:   flag.Reason,
+			Reviewed: flag.Reviewed,
+		})
+	}
+	return flags
+}
+
+// newRPCReviewFlagRequest returns the review of a moderation flag for a
+// request, leaving out the note if not set.
+func newRPCReviewFlagRequest(req *api.ReviewFlagRequest) *rpc.ReviewFlagRequest {
+	rpcReq := &rpc.ReviewFlagRequest{Id: req.Id}
+	if req.Note != "" {
+		rpcReq.Note = &req.Note
+	}
+	return rpcReq
+}
+
+// newAPIIdentityKeys returns the identity keys of a user in the form they are
+// responded with.
+func newAPIIdentityKeys(rpcKeys []*rpc.IdentityKey) []*api.IdentityKey {
+	keys := make([]*api.IdentityKey, 0, len(rpcKeys))
+	for _, key := range rpcKeys {
+		keys = append(keys, &api.IdentityKey{
+			User:      key.User,
+			KeyId:     key.KeyId,
+			Algorithm: key.Algorithm,
+			PublicKey: key.PublicKey,
+			CreatedAt: key.CreatedAt,
+		})
+	}
+	return keys
+}
+
+// authorizeUser fills in user with the authenticated caller if left empty,
+// returning false if it names anyone else.
+func authorizeUser(c *app.RequestContext, user *string) bool {
+	return authorizePrincipal(GetPrincipal(c), user)
+}
+
+// authorizePrincipal fills in user with principal if left empty, returning
+// false if it names anyone else. Any user is allowed if principal is nil, as
+// authentication is disabled.
+func authorizePrincipal(principal *Principal, user *string) bool {
+	if principal == nil {
+		return true
+	}
+
+	if *user == "" {
+		*user = principal.ID
+	}
+	return *user == principal.ID
+}
+
+func blockUser(ctx context.Context, c *app.RequestContext) {
+	var req api.BlockRequest
+	err := c.Bind(&req)
+	if err != nil {
+		writeError(ctx, c, rpc.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("failed to parse request body: %v", err))
+		return
+	}
+
+	if !authorizeUser(c, &req.User) {
+		writeError(ctx, c, rpc.ErrorCode_PERMISSION_DENIED, "user does not match authenticated identity")
+		return
+	}
+
+	resp, err := cli.Block(ctx, &rpc.BlockRequest{
+		User:    req.User,
+		Blocked: req.Blocked,
+	})
+	if err != nil {
+		rpcError(ctx, c, "Block", err)
+	} else if resp.Code != 0 {
+		responseError(ctx, c, resp)
+	} else {
+		render(c, consts.StatusOK, &api.BlockResponse{}, nil)
+	}
+}
+
+func unblockUser(ctx context.Context, c *app.RequestContext) {
+	var req api.UnblockRequest
+	err := c.Bind(&req)
+	if err != nil {
+		writeError(ctx, c, rpc.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("failed to parse request body: %v", err))
+		return
+	}
+
+	if !authorizeUser(c, &req.User) {
+		writeError(ctx, c, rpc.ErrorCode_PERMISSION_DENIED, "user does not match authenticated identity")
+		return
+	}
+
+	resp, err := cli.Unblock(ctx, &rpc.UnblockRequest{
+		User:    req.User,
+		Blocked: req.Blocked,
+	})
+	if err != nil {
+		rpcError(ctx, c, "Unblock", err)
+	} else if resp.Code != 0 {
+		responseError(ctx, c, resp)
+	} else {
+		render(c, consts.StatusOK, &api.UnblockResponse{}, nil)
+	}
+}
+
+func listBlocked(ctx context.Context, c *app.RequestContext) {
+	var req api.ListBlockedRequest
+	err := c.Bind(&req)
+	if err != nil {
+		writeError(ctx, c, rpc.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("failed to parse request body: %v", err))
+		return
+	}
+
+	if !authorizeUser(c, &req.User) {
+		writeError(ctx, c, rpc.ErrorCode_PERMISSION_DENIED, "user does not match authenticated identity")
+		return
+	}
+
+	resp, err := cli.ListBlocked(ctx, &rpc.ListBlockedRequest{
+		User: req.User,
+	})
+	if err != nil {
+		rpcError(ctx, c, "ListBlocked", err)
+		return
+	} else if resp.Code != 0 {
+		responseError(ctx, c, resp)
+		return
+	}
+	render(c, consts.StatusOK, &api.ListBlockedResponse{Blocked: resp.GetBlocked()}, utils.H{"blocked": resp.GetBlocked()})
+}
+
+func queryAuditLog(ctx context.Context, c *app.RequestContext) {
+	var req api.QueryAuditLogRequest
+	err := c.Bind(&req)
+	if err != nil {
+		writeError(ctx, c, rpc.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("failed to parse request body: %v", err))
+		return
+	}
+
+	resp, err := cli.QueryAuditLog(ctx, newRPCQueryAuditLogRequest(&req))
+	if err != nil {
+		rpcError(ctx, c, "QueryAuditLog", err)
+		return
+	} else if resp.Code != 0 {
+		responseError(ctx, c, resp)
+		return
+	}
+	auditResp := &api.QueryAuditLogResponse{
+		Entries:    newAPIAuditEntries(resp.Entries),
+		HasMore:    resp.GetHasMore(),
+		NextCursor: resp.GetNextCursor(),
+	}
+	render(c, consts.StatusOK, auditResp, &QueryAuditLogResponseRest{
+		Entries:    auditResp.Entries,
+		HasMore:    auditResp.HasMore,
+		NextCursor: auditResp.NextCursor,
+	})
+}
+
+// exportMessages responds with a page of an export. Whether the caller may
+// export the chat or user is left to the rpc-server, as administrators may
+// export chats they are not members of.
+func exportMessages(ctx context.Context, c *app.RequestContext) {
+	var req api.ExportMessagesRequest
+	err := c.Bind(&req)
+	if err != nil {
+		writeError(ctx, c, rpc.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("failed to parse request body: %v", err))
+		return
+	}
+
+	resp, err := cli.ExportMessages(ctx, newRPCExportMessagesRequest(&req))
+	if err != nil {
+		rpcError(ctx, c, "ExportMessages", err)
+		return
+	} else if resp.Code != 0 {
+		responseError(ctx, c, resp)
+		return
+	}
+	exportResp := &api.ExportMessagesResponse{
+		Messages:   newAPIMessages(resp.Messages),
+		HasMore:    resp.GetHasMore(),
+		NextCursor: resp.GetNextCursor(),
+	}
+	render(c, consts.StatusOK, exportResp, &ExportMessagesResponseRest{
+		Messages:   exportResp.Messages,
+		HasMore:    exportResp.HasMore,
+		NextCursor: exportResp.NextCursor,
+	})
+}
+
+func listFlags(ctx context.Context, c *app.RequestContext) {
+	var req api.ListFlagsRequest
+	err := c.Bind(&req)
+	if err != nil {
+		writeError(ctx, c, rpc.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("failed to parse request body: %v", err))
+		return
+	}
+
+	resp, err := cli.ListFlags(ctx, newRPCListFlagsRequest(&req))
+	if err != nil {
+		rpcError(ctx, c, "ListFlags", err)
+		return
+	} else if resp.Code != 0 {
+		responseError(ctx, c, resp)
+		return
+	}
+	flagsResp := &api.ListFlagsResponse{
+		Flags:      newAPIModerationFlags(resp.Flags),
 		HasMore:    resp.GetHasMore(),
 		NextCursor: resp.GetNextCursor(),
+	}
+	render(c, consts.StatusOK, flagsResp, &ListFlagsResponseRest{
+		Flags:      flagsResp.Flags,
+		HasMore:    flagsResp.HasMore,
+		NextCursor: flagsResp.NextCursor,
 	})
 }
+
+func reviewFlag(ctx context.Context, c *app.RequestContext) {
+	var req api.ReviewFlagRequest
+	err := c.Bind(&req)
+	if err != nil {
+		writeError(ctx, c, rpc.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("failed to parse request body: %v", err))
+		return
+	}
+
+	resp, err := cli.ReviewFlag(ctx, newRPCReviewFlagRequest(&req))
+	if err != nil {
+		rpcError(ctx, c, "ReviewFlag", err)
+	} else if resp.Code != 0 {
+		responseError(ctx, c, resp)
+	} else {
+		render(c, consts.StatusOK, &api.ReviewFlagResponse{}, nil)
+	}
+}
+
+func publishKey(ctx context.Context, c *app.RequestContext) {
+	var req api.PublishKeyRequest
+	err := c.Bind(&req)
+	if err != nil {
+		writeError(ctx, c, rpc.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("failed to parse request body: %v", err))
+		return
+	}
+
+	if !authorizeUser(c, &req.User) {
+		writeError(ctx, c, rpc.ErrorCode_PERMISSION_DENIED, "user does not match authenticated identity")
+		return
+	}
+
+	resp, err := cli.PublishKey(ctx, &rpc.PublishKeyRequest{
+		Key: &rpc.IdentityKey{
+			User:      req.User,
+			KeyId:     req.KeyId,
+			Algorithm: req.Algorithm,
+			PublicKey: req.PublicKey,
+		},
+	})
+	if err != nil {
+		rpcError(ctx, c, "PublishKey", err)
+	} else if resp.Code != 0 {
+		responseError(ctx, c, resp)
+	} else {
+		render(c, consts.StatusOK, &api.PublishKeyResponse{}, nil)
+	}
+}
+
+func getKeys(ctx context.Context, c *app.RequestContext) {
+	var req api.GetKeysRequest
+	err := c.Bind(&req)
+	if err != nil {
+		writeError(ctx, c, rpc.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("failed to parse request body: %v", err))
+		return
+	}
+
+	resp, err := cli.GetKeys(ctx, &rpc.GetKeysRequest{
+		User: req.User,
+	})
+	if err != nil {
+		rpcError(ctx, c, "GetKeys", err)
+		return
+	} else if resp.Code != 0 {
+		responseError(ctx, c, resp)
+		return
+	}
+	keys := newAPIIdentityKeys(resp.Keys)
+	render(c, consts.StatusOK, &api.GetKeysResponse{Keys: keys}, utils.H{"keys": keys})
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/adaptor"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/kitex/pkg/discovery"
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "imservice",
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests handled, by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "imservice",
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests handled, by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	grpcRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "imservice",
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Number of gRPC and gRPC-Web requests handled, by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "imservice",
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of gRPC and gRPC-Web requests handled, by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	rpcClientRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "imservice",
+		Subsystem: "rpc_client",
+		Name:      "requests_total",
+		Help:      "Number of RPC requests made, by method and response code.",
+	}, []string{"method", "code"})
+
+	rpcClientRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "imservice",
+		Subsystem: "rpc_client",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of RPC requests made, by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	resolverInstances = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "imservice",
+		Subsystem: "resolver",
+		Name:      "instances",
+		Help:      "Number of service instances last resolved, by service.",
+	}, []string{"service"})
+
+	resolverErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "imservice",
+		Subsystem: "resolver",
+		Name:      "errors_total",
+		Help:      "Number of failed service resolutions, by service.",
+	}, []string{"service"})
+)
+
+// HTTPMetricsMiddleware records the count, status and latency of every HTTP request.
+func HTTPMetricsMiddleware(ctx context.Context, c *app.RequestContext) {
+	start := time.Now()
+	c.Next(ctx)
+
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	method := string(c.Method())
+	httpRequestsTotal.WithLabelValues(method, route, strconv.Itoa(c.Response.StatusCode())).Inc()
+	httpRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+}
+
+// codedResponse is implemented by every response in the RPC IDL.
+type codedResponse interface {
+	GetCode() int32
+	GetMsg() string
+}
+
+// RPCMetricsMiddleware records the count, response code and latency of every RPC made.
+func RPCMetricsMiddleware(next endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, req, resp interface{}) error {
+		start := time.Now()
+		err := next(ctx, req, resp)
+
+		method := rpcinfo.GetRPCInfo(ctx).Invocation().MethodName()
+		code := "error"
+		if err == nil {
+			if result, ok := resp.(utils.KitexResult); ok {
+				if response, ok := result.GetResult().(codedResponse); ok {
+					code = strconv.Itoa(int(response.GetCode()))
+				}
+			}
+		}
+
+		rpcClientRequestsTotal.WithLabelValues(method, code).Inc()
+		rpcClientRequestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// InstrumentedResolver records the state of every resolution made by the
+// wrapped resolver.
+type InstrumentedResolver struct {
+	discovery.Resolver
+}
+
+func (r *InstrumentedResolver) Resolve(ctx context.Context, desc string) (discovery.Result, error) {
+	result, err := r.Resolver.Resolve(ctx, desc)
+	if err != nil {
+		resolverErrorsTotal.WithLabelValues(desc).Inc()
+		resolverInstances.WithLabelValues(desc).Set(0)
+		return result, err
+	}
+
+	resolverInstances.WithLabelValues(desc).Set(float64(len(result.Instances)))
+	return result, nil
+}
+
+// MetricsHandler serves the Prometheus metrics of this service.
+func MetricsHandler() app.HandlerFunc {
+	return handlerFromHTTP(promhttp.Handler())
+}
+
+func handlerFromHTTP(handler http.Handler) app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		req, err := adaptor.GetCompatRequest(&c.Request)
+		if err != nil {
+			c.String(consts.StatusInternalServerError, err.Error())
+			return
+		}
+		handler.ServeHTTP(adaptor.GetCompatResponseWriter(&c.Response), req.WithContext(ctx))
+	}
+}
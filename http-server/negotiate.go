@@ -0,0 +1,64 @@
+package main
+
+import (
+	"math"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"google.golang.org/protobuf/proto"
+)
+
+// protobufContentType is the media type of protobuf request and response
+// bodies, which are the messages generated from idl_http.proto. Request bodies
+// of either type are decoded by c.Bind according to their Content-Type.
+const protobufContentType = "application/x-protobuf"
+
+// render responds with msg encoded as protobuf if the client prefers it, and
+// otherwise with body encoded as JSON, or with no body if body is nil. JSON
+// bodies are built separately from msg as the generated types omit zero values.
+func render(c *app.RequestContext, status int, msg proto.Message, body interface{}) {
+	c.Header("Vary", "Accept")
+	if prefersProtobuf(c) {
+		c.ProtoBuf(status, msg)
+	} else if body != nil {
+		c.JSON(status, body)
+	} else {
+		c.Status(status)
+	}
+}
+
+// prefersProtobuf reports whether the Accept header of the request ranks
+// protobuf above JSON or, if there is no Accept header, whether the request
+// body is protobuf. JSON is preferred when both rank equally, including when
+// only wildcards are accepted.
+func prefersProtobuf(c *app.RequestContext) bool {
+	accept := string(c.GetHeader("Accept"))
+	if accept == "" {
+		mediaType, _, _ := mime.ParseMediaType(string(c.ContentType()))
+		return mediaType == protobufContentType
+	}
+
+	protobufQuality, jsonQuality := 0.0, 0.0
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(mediaRange)
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+
+		switch mediaType {
+		case protobufContentType:
+			protobufQuality = math.Max(protobufQuality, quality)
+		case "application/json", "application/*", "*/*":
+			jsonQuality = math.Max(jsonQuality, quality)
+		}
+	}
+	return protobufQuality > jsonQuality
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/proto_gen/api"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPrefersProtobuf(t *testing.T) {
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+		protobuf    bool
+	}{
+		{name: "protobuf", accept: "application/x-protobuf", protobuf: true},
+		{name: "json", accept: "application/json"},
+		{name: "protobuf with parameters", accept: "application/x-protobuf; charset=binary", protobuf: true},
+		{name: "protobuf ranked higher", accept: "application/json;q=0.5, application/x-protobuf", protobuf: true},
+		{name: "json ranked higher", accept: "application/x-protobuf;q=0.5, application/json"},
+		{name: "equal ranks prefer json", accept: "application/x-protobuf, application/json"},
+		{name: "protobuf over wildcard", accept: "application/x-protobuf, */*;q=0.1", protobuf: true},
+		{name: "wildcard only", accept: "*/*"},
+		{name: "application wildcard over protobuf", accept: "application/*, application/x-protobuf;q=0.9"},
+		{name: "protobuf not acceptable", accept: "application/x-protobuf;q=0, */*;q=0.1"},
+		{name: "unrelated types", accept: "text/html"},
+		{name: "malformed range skipped", accept: "application/json;;;, application/x-protobuf", protobuf: true},
+		{name: "malformed quality skipped", accept: "application/json;q=high, application/x-protobuf;q=0.1", protobuf: true},
+		{name: "no accept, protobuf body", contentType: "application/x-protobuf", protobuf: true},
+		{name: "no accept, json body", contentType: "application/json"},
+		{name: "no accept, no body"},
+		{name: "accept overrides body", accept: "application/json", contentType: "application/x-protobuf"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := app.NewContext(0)
+			if test.accept != "" {
+				c.Request.Header.Set("Accept", test.accept)
+			}
+			if test.contentType != "" {
+				c.Request.Header.SetContentTypeBytes([]byte(test.contentType))
+			}
+			assert.Equal(t, test.protobuf, prefersProtobuf(c))
+		})
+	}
+}
+
+func TestSendMessage_Protobuf(t *testing.T) {
+	stub := &stubIMClient{}
+	useTestClient(t, stub)
+
+	engine := route.NewEngine(config.NewOptions(nil))
+	registerAPIRoutes(engine.Group("/api"))
+	body, err := proto.Marshal(&api.SendRequest{Chat: "a:b", Text: "hi", Sender: "a"})
+	if err != nil {
+		t.Fatalf("Error when encoding test request: %+v\n", err)
+	}
+
+	resp := ut.PerformRequest(engine, "POST", "/api/send", &ut.Body{Body: bytes.NewReader(body), Len: len(body)},
+		ut.Header{Key: "Content-Type", Value: protobufContentType}).Result()
+	assert.Equal(t, consts.StatusOK, resp.StatusCode())
+	assert.Equal(t, protobufContentType, string(resp.Header.ContentType()))
+	if sent := stub.sent(); assert.Equal(t, 1, len(sent)) {
+		assert.Equal(t, "a:b", sent[0].Chat)
+		assert.Equal(t, "hi", sent[0].Text)
+		assert.Equal(t, "a", sent[0].Sender)
+	}
+}
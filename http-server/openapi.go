@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+)
+
+// openAPIDocument describes the HTTP API. It is generated from idl_http.proto
+// by cmd/openapi-gen as part of make generate.
+//
+//go:embed openapi.json
+var openAPIDocument []byte
+
+// docsPage renders openAPIDocument with Swagger UI.
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>IM Service HTTP API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5.1.0/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5.1.0/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`
+
+// OpenAPIHandler serves the OpenAPI document of the HTTP API.
+func OpenAPIHandler(ctx context.Context, c *app.RequestContext) {
+	c.Data(consts.StatusOK, "application/json", openAPIDocument)
+}
+
+// DocsHandler serves interactive documentation of the HTTP API.
+func DocsHandler(ctx context.Context, c *app.RequestContext) {
+	c.Data(consts.StatusOK, "text/html; charset=utf-8", []byte(docsPage))
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOpenAPIRoutes fails if the routes of the HTTP API and those described by
+// openapi.json diverge, in which case idl_http.proto or registerAPIRoutes needs
+// updating, followed by make generate.
+func TestOpenAPIRoutes(t *testing.T) {
+	engine := route.NewEngine(config.NewOptions(nil))
+	registerAPIRoutes(engine.Group("/api"))
+
+	var served []string
+	for _, r := range engine.Routes() {
+		served = append(served, r.Method+" "+r.Path)
+	}
+
+	var document struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(openAPIDocument, &document); err != nil {
+		t.Fatalf("Error when parsing openapi.json: %+v\n", err)
+	}
+	var described []string
+	for path, operations := range document.Paths {
+		for method := range operations {
+			described = append(described, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	sort.Strings(served)
+	sort.Strings(described)
+	assert.Equal(t, described, served)
+}
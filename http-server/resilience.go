@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/kitex_gen/rpc"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/kitex/client"
+	"github.com/cloudwego/kitex/pkg/circuitbreak"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/retry"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"golang.org/x/exp/slog"
+)
+
+// idempotentMethods are the RPCs that only read state and so can safely be
+// retried. Send and BatchSend are not among them as retrying them may store
+// messages twice.
+var idempotentMethods = []string{"Pull", "MultiPull", "ListChats", "ListBlocked", "GetKeys", "QueryAuditLog", "ListFlags"}
+
+// unavailableErrors are the errors Kitex returns when the rpc-server could not
+// be reached or did not respond in time, rather than having failed the request.
+var unavailableErrors = []error{
+	kerrors.ErrServiceDiscovery,
+	kerrors.ErrGetConnection,
+	kerrors.ErrLoadbalance,
+	kerrors.ErrNoMoreInstance,
+	kerrors.ErrRPCTimeout,
+	kerrors.ErrCircuitBreak,
+	kerrors.ErrRemoteOrNetwork,
+	kerrors.ErrOverlimit,
+	kerrors.ErrRetry,
+}
+
+// NewResilienceOptions returns the client options retrying the configured
+// idempotent methods on failure, with a fixed backoff and on a different
+// instance, and breaking the circuit to instances failing more than the
+// configured share of calls.
+func NewResilienceOptions(cfg RPCConfig) []client.Option {
+	var opts []client.Option
+
+	if policies := retryPolicies(cfg); len(policies) > 0 {
+		opts = append(opts, client.WithRetryMethodPolicies(policies))
+	}
+
+	if cfg.BreakerErrorRate > 0 {
+		suite := circuitbreak.NewCBSuite(circuitbreak.RPCInfo2Key)
+		suite.UpdateInstanceCBConfig(circuitbreak.CBConfig{
+			Enable:    true,
+			ErrRate:   cfg.BreakerErrorRate,
+			MinSample: int64(cfg.BreakerMinSample),
+		})
+		opts = append(opts, client.WithInstanceMW(suite.InstanceCBMW()))
+	}
+	return opts
+}
+
+// retryPolicies returns the retry policy of each configured method. Kitex only
+// retries timeouts by default, so the policies also retry the errors of an
+// unreachable instance, such as a refused connection.
+func retryPolicies(cfg RPCConfig) map[string]retry.Policy {
+	if cfg.RetryMaxTimes <= 0 {
+		return nil
+	}
+
+	policies := make(map[string]retry.Policy, len(cfg.RetryMethods))
+	for _, method := range cfg.RetryMethods {
+		policy := retry.NewFailurePolicy()
+		policy.WithMaxRetryTimes(cfg.RetryMaxTimes)
+		if backoff := int(cfg.RetryBackoff.Milliseconds()); backoff > 0 {
+			policy.WithFixedBackOff(backoff)
+		}
+		policy.WithSpecifiedResultRetry(&retry.ShouldResultRetry{ErrorRetry: func(err error, _ rpcinfo.RPCInfo) bool {
+			return IsUnavailable(err)
+		}})
+		policies[method] = retry.BuildFailurePolicy(policy)
+	}
+	return policies
+}
+
+func isIdempotent(method string) bool {
+	for _, m := range idempotentMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUnavailable reports whether err means the rpc-server could not serve the
+// request, in which case the request may succeed if retried later.
+func IsUnavailable(err error) bool {
+	for _, target := range unavailableErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// rpcError responds to a request whose RPC failed with err, with 503 Service
+// Unavailable if the rpc-server could not be reached and 500 Internal Server
+// Error otherwise. The error itself is logged rather than returned to the
+// client.
+func rpcError(ctx context.Context, c *app.RequestContext, method string, err error) {
+	slog.ErrorCtx(ctx, "error calling rpc-server", "method", method, "error", err)
+	if IsUnavailable(err) {
+		c.Header("Retry-After", "1")
+		writeError(ctx, c, rpc.ErrorCode_UNAVAILABLE, "message service unavailable, please retry later")
+		return
+	}
+	writeError(ctx, c, rpc.ErrorCode_INTERNAL, "internal error")
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/http-server/kitex_gen/rpc"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewResilienceOptions(t *testing.T) {
+	cfg := RPCConfig{RetryMaxTimes: 2, RetryMethods: []string{"Pull", "ListChats"}, RetryBackoff: 10 * time.Millisecond, BreakerErrorRate: 0.5, BreakerMinSample: 10}
+	assert.Equal(t, 2, len(NewResilienceOptions(cfg)))
+
+	cfg.RetryMaxTimes = 0
+	cfg.BreakerErrorRate = 0
+	assert.Equal(t, 0, len(NewResilienceOptions(cfg)))
+}
+
+func TestRetryPolicies(t *testing.T) {
+	policies := retryPolicies(RPCConfig{RetryMaxTimes: 2, RetryMethods: []string{"Pull"}, RetryBackoff: 10 * time.Millisecond})
+	if !assert.Equal(t, 1, len(policies)) {
+		return
+	}
+	policy := policies["Pull"].FailurePolicy
+	assert.Equal(t, 2, policy.StopPolicy.MaxRetryTimes)
+
+	// Errors of an unreachable instance are retried, failed requests are not
+	shouldRetry := policy.ShouldResultRetry.ErrorRetry
+	assert.True(t, shouldRetry(kerrors.ErrGetConnection.WithCause(errors.New("connection refused")), nil))
+	assert.True(t, shouldRetry(kerrors.ErrRemoteOrNetwork, nil))
+	assert.False(t, shouldRetry(errors.New("biz error"), nil))
+
+	assert.Nil(t, retryPolicies(RPCConfig{RetryMethods: []string{"Pull"}}))
+}
+
+func TestIsUnavailable(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		unavailable bool
+	}{
+		{name: "no instances", err: kerrors.ErrNoMoreInstance, unavailable: true},
+		{name: "connection refused", err: kerrors.ErrGetConnection.WithCause(errors.New("connection refused")), unavailable: true},
+		{name: "timeout", err: kerrors.ErrRPCTimeout.WithCause(errors.New("deadline exceeded")), unavailable: true},
+		{name: "circuit broken", err: kerrors.ErrCircuitBreak, unavailable: true},
+		{name: "service discovery", err: kerrors.ErrServiceDiscovery, unavailable: true},
+		{name: "internal", err: kerrors.ErrInternalException},
+		{name: "other", err: errors.New("decode error")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.unavailable, IsUnavailable(test.err))
+		})
+	}
+}
+
+func TestRPCError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		status     int
+		code       rpc.ErrorCode
+		retryAfter string
+	}{
+		{name: "unavailable", err: kerrors.ErrNoMoreInstance, status: consts.StatusServiceUnavailable, code: rpc.ErrorCode_UNAVAILABLE, retryAfter: "1"},
+		{name: "internal", err: errors.New("decode error"), status: consts.StatusInternalServerError, code: rpc.ErrorCode_INTERNAL},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			engine := route.NewEngine(config.NewOptions(nil))
+			engine.GET("/fail", func(ctx context.Context, c *app.RequestContext) {
+				rpcError(ctx, c, "Pull", test.err)
+			})
+
+			resp := ut.PerformRequest(engine, "GET", "/fail", nil).Result()
+			assert.Equal(t, test.status, resp.StatusCode())
+			assert.Equal(t, test.retryAfter, resp.Header.Get("Retry-After"))
+			var errBody struct {
+				Code    int32  `json:"code"`
+				Message string `json:"message"`
+			}
+			assert.Nil(t, json.Unmarshal(resp.Body(), &errBody))
+			assert.Equal(t, int32(test.code), errBody.Code)
+			assert.NotContains(t, errBody.Message, test.err.Error())
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/common/s2s"
+	"github.com/bytedance/gopkg/cloud/metainfo"
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// CallerSignMiddleware attaches this service's identity, the authenticated
+// end user (if any) and their signature, which also covers the request, to
+// every outgoing RPC.
+func CallerSignMiddleware(secret []byte, service string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req, resp interface{}) error {
+			body, err := s2s.SerializeRequest(ctx, req)
+			if err != nil {
+				return err
+			}
+
+			md := s2s.Metadata{
+				Service:   service,
+				Method:    rpcinfo.GetRPCInfo(ctx).Invocation().MethodName(),
+				Timestamp: strconv.FormatInt(time.Now().UTC().UnixMicro(), 10),
+			}
+			if principal := principalFromContext(ctx); principal != nil {
+				md.User = principal.ID
+			}
+			ctx = metainfo.WithValue(ctx, s2s.ServiceMetaKey, md.Service)
+			ctx = metainfo.WithValue(ctx, s2s.UserMetaKey, md.User)
+			ctx = metainfo.WithValue(ctx, s2s.TimestampMetaKey, md.Timestamp)
+			ctx = metainfo.WithValue(ctx, s2s.SignatureMetaKey, s2s.Sign(secret, md, body))
+			return next(ctx, req, resp)
+		}
+	}
+}
+
+// NewCallerSignMiddlewareFromConfig returns the caller signing middleware if a
+// shared secret is configured, nil otherwise.
+func NewCallerSignMiddlewareFromConfig(cfg S2SConfig, service string) endpoint.Middleware {
+	if cfg.SharedSecret == "" {
+		return nil
+	}
+	return CallerSignMiddleware([]byte(cfg.SharedSecret), service)
+}
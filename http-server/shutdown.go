@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// shuttingDown is set once the server begins shutting down, failing readiness
+// checks from then on.
+var shuttingDown atomic.Bool
+
+// ShutdownConfig controls how the server shuts down on SIGINT or SIGTERM. The
+// server first fails readiness checks and keeps serving for Delay, giving load
+// balancers time to stop routing to it, then stops accepting connections and
+// waits up to Timeout for in-flight requests to complete.
+type ShutdownConfig struct {
+	Delay   time.Duration `yaml:"delay" env:"SHUTDOWN_DELAY"`
+	Timeout time.Duration `yaml:"timeout" env:"SHUTDOWN_TIMEOUT"`
+}
+
+// SignalWaiter is a Hertz signal waiter that shuts down gracefully on both
+// SIGINT and SIGTERM, unlike the default which closes immediately on SIGTERM.
+func (config ShutdownConfig) SignalWaiter(errCh chan error) error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	select {
+	case sig := <-signals:
+		slog.Info("shutting down", "signal", sig.String(), "delay", config.Delay, "timeout", config.Timeout)
+		shuttingDown.Store(true)
+		time.Sleep(config.Delay)
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/common/tracing"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/utils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const tracerName = "github.com/TikTokTechImmersion/assignment_demo_2023/http-server"
+
+var tracer = otel.Tracer(tracerName)
+
+// headerCarrier reads the trace context of an incoming HTTP request.
+type headerCarrier struct {
+	c *app.RequestContext
+}
+
+func (h headerCarrier) Get(key string) string {
+	return string(h.c.GetHeader(key))
+}
+
+func (h headerCarrier) Set(key string, value string) {}
+
+func (h headerCarrier) Keys() []string {
+	var keys []string
+	h.c.Request.Header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
+
+// HTTPTracingMiddleware continues any trace propagated by the client and starts
+// a server span for every HTTP request, which the handlers' RPCs are children of.
+func HTTPTracingMiddleware(ctx context.Context, c *app.RequestContext) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier{c})
+
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	method := string(c.Method())
+	ctx, span := tracer.Start(ctx, method+" "+route, trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.HTTPMethodKey.String(method), semconv.HTTPRouteKey.String(route)))
+	defer span.End()
+
+	c.Next(ctx)
+
+	status := c.Response.StatusCode()
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+	if status >= 500 {
+		span.SetStatus(codes.Error, "")
+	}
+}
+
+// metadataCarrier reads the trace context of an incoming gRPC call.
+type metadataCarrier metadata.MD
+
+func (m metadataCarrier) Get(key string) string {
+	if values := metadata.MD(m).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (m metadataCarrier) Set(key string, value string) {}
+
+func (m metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// GRPCTracingInterceptor is the gRPC counterpart of HTTPTracingMiddleware,
+// continuing any trace propagated in the metadata of the call.
+func GRPCTracingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+	// FullMethod is "/package.Service/Method"
+	name := strings.TrimPrefix(info.FullMethod, "/")
+	service, method, _ := strings.Cut(name, "/")
+	ctx, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.RPCSystemKey.String("grpc"), semconv.RPCServiceKey.String(service), semconv.RPCMethodKey.String(method)))
+	defer span.End()
+
+	resp, err := handler(ctx, req)
+
+	code := status.Code(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+	if code != grpccodes.OK {
+		span.SetStatus(codes.Error, code.String())
+	}
+	return resp, err
+}
+
+// RPCTracingMiddleware starts a client span for every RPC made and propagates
+// it to the rpc-server.
+func RPCTracingMiddleware(next endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, req, resp interface{}) error {
+		method := rpcinfo.GetRPCInfo(ctx).Invocation().MethodName()
+		ctx, span := tracer.Start(ctx, "IMService/"+method, trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.RPCSystemKey.String("kitex"), semconv.RPCServiceKey.String("IMService"), semconv.RPCMethodKey.String(method)))
+		defer span.End()
+
+		carrier := &tracing.MetainfoCarrier{Ctx: ctx}
+		otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+		err := next(carrier.Ctx, req, resp)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return err
+		}
+		if result, ok := resp.(utils.KitexResult); ok {
+			if response, ok := result.GetResult().(codedResponse); ok {
+				span.SetAttributes(attribute.Int("imservice.code", int(response.GetCode())))
+			}
+		}
+		return nil
+	}
+}
@@ -1,11 +1,32 @@
 // API for pull mode IM service.
 namespace go rpc
 
+// Codes returned in the Code field of every response. The values are stable,
+// clients should branch on them rather than on Msg.
+enum ErrorCode {
+    SUCCESS = 0
+    INTERNAL = -1          // unexpected server error
+    INVALID_ARGUMENT = 1   // malformed chat, message, user or key
+    INVALID_LIMIT = 2
+    INVALID_CURSOR = 3
+    PERMISSION_DENIED = 4
+    RATE_LIMITED = 5       // retry after RetryAfter
+    BLOCKED = 6            // sender is blocked by the receiver
+    MESSAGE_REJECTED = 7   // rejected by moderation
+    CONFLICT = 8           // e.g. a different key was published with the same key id
+    NOT_FOUND = 9
+    UNAVAILABLE = 10       // the RPC service could not be reached, only returned by the HTTP API
+    UNAUTHENTICATED = 11   // only returned by the HTTP API
+}
+
 struct Message {
     1: string Chat   // format "<member1>:<member2>", e.g. "john:doe"
     2: string Text   // message text content
     3: string Sender // sender identifier
     4: i64 SendTime  // unit: microseconds
+    5: optional bool Encrypted        // if true, Text is an opaque end-to-end encrypted envelope
+    6: optional string SenderKeyId    // identity key of the sender used for the envelope
+    7: optional string RecipientKeyId // identity key of the receiver used for the envelope
 }
 
 struct SendRequest {
@@ -13,8 +34,9 @@ struct SendRequest {
 }
 
 struct SendResponse {
-    1: required i32 Code   // zero for success, non-zero for failures
-    2: required string Msg // prompt information
+    1: required i32 Code         // an ErrorCode, zero for success
+    2: required string Msg       // prompt information
+    3: optional i64 RetryAfter   // unit: milliseconds, set when the request is rate limited
 }
 
 struct PullRequest {
@@ -25,14 +47,280 @@ struct PullRequest {
 }
 
 struct PullResponse {
-    1: required i32 Code   // zero for success, non-zero for failures
+    1: required i32 Code   // an ErrorCode, zero for success
     2: required string Msg // prompt information
     3: optional list<Message> Messages
     4: optional bool HasMore   // if true, can use next_cursor to pull the next page of messages
     5: optional i64 NextCursor // starting position of next page, inclusively
 }
 
+struct SendStatus {
+    1: required i32 Code         // an ErrorCode, zero for success
+    2: required string Msg       // prompt information
+    3: optional i64 RetryAfter   // unit: milliseconds, set when the message is rate limited
+}
+
+struct BatchSendRequest {
+    1: required list<Message> Messages // at most 100 messages, stored in a single transaction per chat
+}
+
+struct BatchSendResponse {
+    1: required i32 Code   // an ErrorCode, zero unless the batch as a whole is rejected
+    2: required string Msg // prompt information
+    3: optional list<SendStatus> Statuses // status of each message, in the order they were sent
+}
+
+struct MultiPullRequest {
+    1: required list<string> Chats // at most 50 chats, format "<member1>:<member2>"
+    2: required i32 Limit          // the maximum number of messages returned per chat, 10 by default
+    3: optional bool Reverse       // if false, the results will be sorted in ascending order by time
+}
+
+struct ChatPage {
+    1: required string Chat    // normalised chat
+    2: required i32 Code       // an ErrorCode, zero for success
+    3: required string Msg     // prompt information
+    4: optional list<Message> Messages
+    5: optional bool HasMore   // if true, can use next_cursor to pull the next page with Pull
+    6: optional i64 NextCursor // starting position of next page, inclusively
+}
+
+struct MultiPullResponse {
+    1: required i32 Code   // an ErrorCode, zero unless the request as a whole is rejected
+    2: required string Msg // prompt information
+    3: optional list<ChatPage> Chats // first page of each chat, in the order they were requested
+}
+
+struct ChatSummary {
+    1: string Chat         // normalised chat
+    2: i64 LastSendTime    // send time of the latest message, unit: microseconds
+    3: i64 MessageCount
+}
+
+struct ListChatsRequest {
+    1: required string User
+    2: required i64 Cursor // starting position of the page, 0 by default
+    3: required i32 Limit  // the maximum number of chats returned per request, 50 by default
+}
+
+struct ListChatsResponse {
+    1: required i32 Code   // an ErrorCode, zero for success
+    2: required string Msg // prompt information
+    3: optional list<ChatSummary> Chats // chats the user sent or received messages in, most recently active first
+    4: optional bool HasMore   // if true, can use next_cursor to list the next page of chats
+    5: optional i64 NextCursor // starting position of next page, inclusively
+}
+
+struct BlockRequest {
+    1: required string User    // user doing the blocking
+    2: required string Blocked // user to be blocked
+}
+
+struct BlockResponse {
+    1: required i32 Code   // an ErrorCode, zero for success
+    2: required string Msg // prompt information
+}
+
+struct UnblockRequest {
+    1: required string User    // user doing the unblocking
+    2: required string Blocked // user to be unblocked
+}
+
+struct UnblockResponse {
+    1: required i32 Code   // an ErrorCode, zero for success
+    2: required string Msg // prompt information
+}
+
+struct ListBlockedRequest {
+    1: required string User
+}
+
+struct ListBlockedResponse {
+    1: required i32 Code   // an ErrorCode, zero for success
+    2: required string Msg // prompt information
+    3: optional list<string> Blocked
+}
+
+struct AuditEntry {
+    1: i64 Id
+    2: string Action        // e.g. "block", "unblock"
+    3: string Actor         // user performing the action, empty if performed by a service
+    4: string ActorService  // service through which the action was performed
+    5: string TargetChat    // normalised chat affected, if any
+    6: string TargetMessage // message affected, if any
+    7: string TargetUser    // user affected, if any
+    8: string Details       // free form details of the action
+    9: i64 CreatedAt        // unit: microseconds
+}
+
+struct QueryAuditLogRequest {
+    1: optional string Actor
+    2: optional string Action
+    3: optional string TargetChat
+    4: optional string TargetUser
+    5: optional i64 Since    // unit: microseconds, inclusive
+    6: optional i64 Until    // unit: microseconds, exclusive
+    7: required i64 Cursor   // starting entry id, inclusively, 0 by default
+    8: required i32 Limit    // the maximum number of entries returned per request, 50 by default
+}
+
+struct QueryAuditLogResponse {
+    1: required i32 Code   // an ErrorCode, zero for success
+    2: required string Msg // prompt information
+    3: optional list<AuditEntry> Entries
+    4: optional bool HasMore   // if true, can use next_cursor to query the next page of entries
+    5: optional i64 NextCursor // starting entry id of next page, inclusively
+}
+
+struct ModerationFlag {
+    1: i64 Id
+    2: string Chat     // normalised chat the message was sent to
+    3: string Sender
+    4: string Text     // message text as sent, before any filter masked it
+    5: i64 SendTime    // unit: microseconds
+    6: string Filter   // filter raising the flag, e.g. "links"
+    7: string Reason
+    8: bool Reviewed
+}
+
+struct ListFlagsRequest {
+    1: optional string Chat
+    2: optional bool IncludeReviewed // if true, flags already reviewed are listed too
+    3: required i64 Cursor           // starting flag id, inclusively, 0 by default
+    4: required i32 Limit            // the maximum number of flags returned per request, 50 by default
+}
+
+struct ListFlagsResponse {
+    1: required i32 Code   // an ErrorCode, zero for success
+    2: required string Msg // prompt information
+    3: optional list<ModerationFlag> Flags
+    4: optional bool HasMore   // if true, can use next_cursor to query the next page of flags
+    5: optional i64 NextCursor // starting flag id of next page, inclusively
+}
+
+struct ReviewFlagRequest {
+    1: required i64 Id
+    2: optional string Note // recorded in the audit entry of the review
+}
+
+struct ReviewFlagResponse {
+    1: required i32 Code   // an ErrorCode, zero for success, CONFLICT if the flag was already reviewed
+    2: required string Msg // prompt information
+}
+
+struct IdentityKey {
+    1: string User
+    2: string KeyId
+    3: string Algorithm // e.g. "x25519"
+    4: string PublicKey // base64 encoded public key
+    5: i64 CreatedAt    // unit: microseconds
+}
+
+struct PublishKeyRequest {
+    1: required IdentityKey key
+}
+
+struct PublishKeyResponse {
+    1: required i32 Code   // an ErrorCode, zero for success
+    2: required string Msg // prompt information
+}
+
+struct GetKeysRequest {
+    1: required string User
+}
+
+struct GetKeysResponse {
+    1: required i32 Code   // an ErrorCode, zero for success
+    2: required string Msg // prompt information
+    3: optional list<IdentityKey> Keys
+}
+
+// ExportMessages pages rather than streams, as Kitex streams only Protobuf
+// services over gRPC.
+struct ExportMessagesRequest {
+    1: optional string Chat // chat to export, either Chat or User must be set
+    2: optional string User // user to export every chat of
+    3: optional string Cursor // next_cursor of the previous page, empty for the first page
+    4: required i32 Limit     // the maximum number of messages returned per request, 500 by default
+    5: optional bool Reverse  // if true, chats and their messages are exported in descending order
+}
+
+struct ExportMessagesResponse {
+    1: required i32 Code   // an ErrorCode, zero for success
+    2: required string Msg // prompt information
+    3: optional list<Message> Messages // ordered by chat, then by send time
+    4: optional bool HasMore   // if true, can use next_cursor to export the next page of messages
+    5: optional string NextCursor // opaque position after the last message of the page
+}
+
+struct ImportMessagesRequest {
+    1: required list<Message> Messages // at most 1000, stored with their send times as given
+}
+
+struct ImportMessagesResponse {
+    1: required i32 Code   // an ErrorCode, zero for success
+    2: required string Msg // prompt information
+    3: optional i64 Imported // number of messages stored
+    4: optional i64 Skipped  // number of messages already stored, or repeated in the request
+}
+
+// How the messages an erased user sent are erased. Either way the user is
+// replaced by a pseudonym in every chat they were in.
+enum ErasureMode {
+    REDACT = 1 // keep the messages, replacing their text with "[redacted]"
+    DELETE = 2 // delete the messages
+}
+
+struct EraseUserRequest {
+    1: required string User
+    2: required ErasureMode Mode
+}
+
+struct ErasedChat {
+    1: string Chat            // normalised chat before erasure
+    2: string ErasedChat      // normalised chat after erasure, with the user replaced by the pseudonym
+    3: i64 MessagesRedacted   // messages sent by the user, their text replaced
+    4: i64 MessagesDeleted    // messages sent by the user, deleted
+    5: i64 MessagesAnonymised // messages received by the user, kept with the user replaced
+    6: i64 CursorsPurged      // cached cursors of the chat deleted
+}
+
+struct ErasureReport {
+    1: string User
+    2: string Pseudonym         // replaces the user in the erased chats
+    3: ErasureMode Mode
+    4: list<ErasedChat> Chats
+    5: i64 FlagsDeleted         // moderation flags raised on messages sent by the user
+    6: i64 KeysDeleted          // identity keys published by the user
+    7: i64 BlocksDeleted        // blocks by or of the user
+    8: i64 RateLimitsDeleted    // rate limit buckets of the user and of the erased chats
+    9: i64 RemainingReferences  // rows still referring to the user, checked before committing, always zero
+    10: i64 AuditId             // audit entry recording the erasure
+    11: i64 ErasedAt            // unit: microseconds
+    12: i64 OutboxEventsDeleted // change events of the erased chats already published or of the user's messages
+}
+
+struct EraseUserResponse {
+    1: required i32 Code   // an ErrorCode, zero for success
+    2: required string Msg // prompt information
+    3: optional ErasureReport Report
+}
+
 service IMService {
     SendResponse Send(1: SendRequest req)
     PullResponse Pull(2: PullRequest req)
+    BlockResponse Block(3: BlockRequest req)
+    UnblockResponse Unblock(4: UnblockRequest req)
+    ListBlockedResponse ListBlocked(5: ListBlockedRequest req)
+    QueryAuditLogResponse QueryAuditLog(6: QueryAuditLogRequest req) // admin only
+    PublishKeyResponse PublishKey(7: PublishKeyRequest req)
+    GetKeysResponse GetKeys(8: GetKeysRequest req)
+    BatchSendResponse BatchSend(9: BatchSendRequest req)
+    MultiPullResponse MultiPull(10: MultiPullRequest req)
+    ListChatsResponse ListChats(11: ListChatsRequest req)
+    ExportMessagesResponse ExportMessages(12: ExportMessagesRequest req) // admins, or members of the chats exported
+    ImportMessagesResponse ImportMessages(13: ImportMessagesRequest req) // admin only
+    EraseUserResponse EraseUser(14: EraseUserRequest req) // admin only
+    ListFlagsResponse ListFlags(15: ListFlagsRequest req) // admin only
+    ReviewFlagResponse ReviewFlag(16: ReviewFlagRequest req) // admin only
 }
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc"
+	"gorm.io/gorm"
+)
+
+// Actions recorded in the audit log.
+const (
+	AuditActionBlock   = "block"
+	AuditActionUnblock = "unblock"
+	AuditActionExport  = "export"
+	AuditActionImport  = "import"
+	AuditActionErase   = "erase"
+
+	AuditActionReviewFlag = "review_flag"
+)
+
+var auditLogImmutableErr = errors.New("audit log entries cannot be modified")
+
+// AuditLog is an append-only record of an administrative or destructive action.
+type AuditLog struct {
+	ID            uint64 `gorm:"primaryKey"`
+	Action        string `gorm:"index"`
+	Actor         string `gorm:"index"`
+	ActorService  string
+	TargetChat    string `gorm:"index"`
+	TargetMessage string
+	TargetUser    string `gorm:"index"`
+	Details       string
+	CreatedAt     uint64 `gorm:"index;autoCreateTime:false"`
+}
+
+// auditLogTriggers are the statements creating, for each dialect, the triggers
+// rejecting changes to audit log entries in the database itself, so that they
+// hold for raw SQL and sessions skipping hooks as well as the hooks below.
+var auditLogTriggers = map[string][]string{
+	"postgres": {
+		`CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit log entries cannot be modified';
+END
+$$ LANGUAGE plpgsql`,
+		`DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs`,
+		`CREATE TRIGGER audit_logs_append_only BEFORE UPDATE OR DELETE ON audit_logs
+	FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only()`,
+		`DROP TRIGGER IF EXISTS audit_logs_no_truncate ON audit_logs`,
+		`CREATE TRIGGER audit_logs_no_truncate BEFORE TRUNCATE ON audit_logs
+	FOR EACH STATEMENT EXECUTE FUNCTION audit_logs_append_only()`,
+	},
+	"sqlite": {
+		`CREATE TRIGGER IF NOT EXISTS audit_logs_no_update BEFORE UPDATE ON audit_logs
+BEGIN
+	SELECT RAISE(ABORT, 'audit log entries cannot be modified');
+END`,
+		`CREATE TRIGGER IF NOT EXISTS audit_logs_no_delete BEFORE DELETE ON audit_logs
+BEGIN
+	SELECT RAISE(ABORT, 'audit log entries cannot be modified');
+END`,
+	},
+}
+
+// migrateAuditLog creates the triggers keeping the audit log append-only, after
+// its table is migrated. The statements run in one transaction so that replicas
+// starting at once do not interleave them.
+func migrateAuditLog(db *gorm.DB) error {
+	statements, ok := auditLogTriggers[db.Dialector.Name()]
+	if !ok {
+		return fmt.Errorf("no audit log triggers for dialect %s", db.Dialector.Name())
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (entry *AuditLog) BeforeUpdate(tx *gorm.DB) error {
+	return auditLogImmutableErr
+}
+
+func (entry *AuditLog) BeforeDelete(tx *gorm.DB) error {
+	return auditLogImmutableErr
+}
+
+func (entry *AuditLog) ToResponse() *rpc.AuditEntry {
+	return &rpc.AuditEntry{
+		Id:            int64(entry.ID),
+		Action:        entry.Action,
+		Actor:         entry.Actor,
+		ActorService:  entry.ActorService,
+		TargetChat:    entry.TargetChat,
+		TargetMessage: entry.TargetMessage,
+		TargetUser:    entry.TargetUser,
+		Details:       entry.Details,
+		CreatedAt:     int64(entry.CreatedAt),
+	}
+}
+
+// RecordAudit appends entry to the audit log using tx, which should be the
+// transaction performing the audited action. The actor is taken from the
+// verified caller if not already set.
+func RecordAudit(ctx context.Context, tx *gorm.DB, entry *AuditLog) error {
+	if caller := GetCaller(ctx); caller != nil {
+		if entry.Actor == "" {
+			entry.Actor = caller.User
+		}
+		entry.ActorService = caller.Service
+	}
+	entry.CreatedAt = uint64(GetTimeNow().UnixMicro())
+	return tx.Create(entry).Error
+}
+
+var adminConfig AdminConfig
+
+// InitAdmin sets the users and services treated as administrators.
+func InitAdmin(cfg AdminConfig) {
+	adminConfig = cfg
+}
+
+// IsAdmin reports whether the verified caller is an administrator, either as one
+// of the configured admin users or, for calls not made for a user, one of the
+// configured admin services.
+func IsAdmin(ctx context.Context) bool {
+	caller := GetCaller(ctx)
+	if caller == nil {
+		return false
+	}
+
+	if caller.User != "" {
+		return Contains(adminConfig.Users, caller.User)
+	}
+	return Contains(adminConfig.Services, caller.Service)
+}
+
+func queryAuditLog(ctx context.Context, req *rpc.QueryAuditLogRequest) ([]*AuditLog, error) {
+	query := GetDatabase().WithContext(ctx).Where("id >= ?", req.GetCursor())
+	if req.IsSetActor() {
+		query = query.Where("actor = ?", req.GetActor())
+	}
+	if req.IsSetAction() {
+		query = query.Where("action = ?", req.GetAction())
+	}
+	if req.IsSetTargetChat() {
+		query = query.Where("target_chat = ?", GetNormalisedChatID(req.GetTargetChat()))
+	}
+	if req.IsSetTargetUser() {
+		query = query.Where("target_user = ?", req.GetTargetUser())
+	}
+	if req.IsSetSince() {
+		query = query.Where("created_at >= ?", req.GetSince())
+	}
+	if req.IsSetUntil() {
+		query = query.Where("created_at < ?", req.GetUntil())
+	}
+
+	var entries []*AuditLog
+	err := query.Order("id ASC").Limit(int(req.GetLimit()) + 1).Find(&entries).Error
+	return entries, err
+}
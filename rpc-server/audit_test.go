@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAuditLog_AppendOnly(t *testing.T) {
+	db := GetDatabase()
+	entry := &AuditLog{Action: "test", Actor: "audit_appendonly"}
+	if err := RecordAudit(context.Background(), db, entry); err != nil {
+		t.Fatalf("Error when recording audit entry: %+v\n", err)
+	}
+
+	err := db.Model(entry).Update("actor", "someone_else").Error
+	assert.Truef(t, errors.Is(err, auditLogImmutableErr), "expected error: %+v, got: %+v", auditLogImmutableErr, err)
+
+	err = db.Delete(entry).Error
+	assert.Truef(t, errors.Is(err, auditLogImmutableErr), "expected error: %+v, got: %+v", auditLogImmutableErr, err)
+
+	// The database rejects changes made without the hooks too
+	err = db.Exec("UPDATE audit_logs SET actor = ? WHERE id = ?", "someone_else", entry.ID).Error
+	assert.ErrorContains(t, err, "audit log entries cannot be modified")
+	err = db.Session(&gorm.Session{SkipHooks: true}).Delete(entry).Error
+	assert.ErrorContains(t, err, "audit log entries cannot be modified")
+
+	// Migrating again, as every replica does on start, keeps the triggers
+	assert.Nil(t, migrateAuditLog(db))
+	err = db.Exec("DELETE FROM audit_logs WHERE id = ?", entry.ID).Error
+	assert.ErrorContains(t, err, "audit log entries cannot be modified")
+
+	var count int64
+	db.Model(&AuditLog{}).Where("actor = ?", "audit_appendonly").Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestIMServiceImpl_QueryAuditLog(t *testing.T) {
+	InitAdmin(AdminConfig{Users: []string{"audit_admin"}})
+	defer InitAdmin(AdminConfig{})
+	s := &IMServiceImpl{}
+	userCtx := context.WithValue(context.Background(), CallerContextKey, &Caller{Service: "http-server", User: "audit_a"})
+	adminCtx := context.WithValue(context.Background(), CallerContextKey, &Caller{Service: "http-server", User: "audit_admin"})
+
+	for _, blocked := range []string{"audit_b", "audit_c", "audit_d"} {
+		if _, err := s.Block(userCtx, &rpc.BlockRequest{User: "audit_a", Blocked: blocked}); err != nil {
+			t.Fatalf("Error when blocking user: %+v\n", err)
+		}
+	}
+	if _, err := s.Unblock(userCtx, &rpc.UnblockRequest{User: "audit_a", Blocked: "audit_b"}); err != nil {
+		t.Fatalf("Error when unblocking user: %+v\n", err)
+	}
+	// Unblocking a user who is not blocked changes nothing and should not be recorded
+	if _, err := s.Unblock(userCtx, &rpc.UnblockRequest{User: "audit_a", Blocked: "audit_b"}); err != nil {
+		t.Fatalf("Error when unblocking user: %+v\n", err)
+	}
+
+	resp, err := s.QueryAuditLog(userCtx, &rpc.QueryAuditLogRequest{Actor: strPtr("audit_a")})
+	assert.Nil(t, err)
+	assert.Equal(t, permissionDeniedErr.Error(), resp.GetMsg())
+	assert.Equal(t, int32(4), resp.GetCode())
+
+	resp, err = s.QueryAuditLog(context.Background(), &rpc.QueryAuditLogRequest{Actor: strPtr("audit_a")})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(4), resp.GetCode(), "expected unverified caller to be denied")
+
+	resp, err = s.QueryAuditLog(adminCtx, &rpc.QueryAuditLogRequest{Actor: strPtr("audit_a")})
+	assert.Nil(t, err)
+	if assert.Equal(t, 4, len(resp.GetEntries())) {
+		assert.Equal(t, AuditActionBlock, resp.GetEntries()[0].GetAction())
+		assert.Equal(t, "audit_b", resp.GetEntries()[0].GetTargetUser())
+		assert.Equal(t, "http-server", resp.GetEntries()[0].GetActorService())
+		assert.Equal(t, AuditActionUnblock, resp.GetEntries()[3].GetAction())
+	}
+	assert.False(t, resp.GetHasMore())
+
+	resp, err = s.QueryAuditLog(adminCtx, &rpc.QueryAuditLogRequest{Actor: strPtr("audit_a"), Action: strPtr(AuditActionBlock), Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(resp.GetEntries()))
+	assert.True(t, resp.GetHasMore())
+
+	resp, err = s.QueryAuditLog(adminCtx, &rpc.QueryAuditLogRequest{Actor: strPtr("audit_a"), Action: strPtr(AuditActionBlock), Limit: 2, Cursor: resp.GetNextCursor()})
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(resp.GetEntries())) {
+		assert.Equal(t, "audit_d", resp.GetEntries()[0].GetTargetUser())
+	}
+	assert.False(t, resp.GetHasMore())
+}
+
+func strPtr(val string) *string {
+	return &val
+}
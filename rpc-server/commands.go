@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/common/configload"
+	"github.com/TikTokTechImmersion/assignment_demo_2023/common/logging"
+	"golang.org/x/exp/slog"
+)
+
+// cliCaller is the caller recorded in the audit log for the actions of the
+// administrative commands, which run with the database credentials of the
+// server rather than on behalf of a caller.
+var cliCaller = &Caller{Service: "rpc-server-cli"}
+
+// adminCommands are run by giving their name as the first argument of
+// rpc-server, in place of serving.
+var adminCommands = map[string]func(args []string, stdout io.Writer) error{
+	"export": runExportCommand,
+	"import": runImportCommand,
+}
+
+// RunAdminCommand runs the administrative command name with args, returning the
+// exit status of the process.
+func RunAdminCommand(name string, args []string, stdout io.Writer) int {
+	err := adminCommands[name](args, stdout)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "rpc-server %s: %v\n", name, err)
+		return 1
+	}
+	return 0
+}
+
+func runExportCommand(args []string, stdout io.Writer) (err error) {
+	cfg := DefaultConfig()
+	fs := flag.NewFlagSet("rpc-server export", flag.ContinueOnError)
+	chat := fs.String("chat", "", "chat to export")
+	user := fs.String("user", "", "user to export every chat of")
+	file := fs.String("out", "", "file to write to instead of standard output")
+	if printed, err := configload.LoadWithFlags(cfg, fs, args, stdout); err != nil || printed {
+		return err
+	}
+	if err := ValidateExportTarget(*chat, *user); err != nil {
+		return err
+	}
+
+	logging.Init(cfg.Logging.Level, cfg.Logging.Format)
+	InitDatabase(cfg.Database)
+	defer CloseDatabase()
+
+	out := stdout
+	if *file != "" {
+		var f *os.File
+		if f, err = os.Create(*file); err != nil {
+			return err
+		}
+		// Closing can fail to write the end of the export
+		defer func() {
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		out = f
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx = context.WithValue(ctx, CallerContextKey, cliCaller)
+
+	w := bufio.NewWriter(out)
+	written, err := WriteExport(ctx, w, *chat, *user)
+	if err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	slog.Info("exported messages", "chat", *chat, "user", *user, "messages", written)
+	return nil
+}
+
+func runImportCommand(args []string, stdout io.Writer) error {
+	cfg := DefaultConfig()
+	fs := flag.NewFlagSet("rpc-server import", flag.ContinueOnError)
+	file := fs.String("in", "", "file to read from instead of standard input")
+	batchSize := fs.Int("batch-size", 500, "number of messages imported per transaction")
+	if printed, err := configload.LoadWithFlags(cfg, fs, args, stdout); err != nil || printed {
+		return err
+	}
+	if *batchSize < 1 || *batchSize > maxImportMessages {
+		return fmt.Errorf("batch-size must be between 1 and %d", maxImportMessages)
+	}
+
+	logging.Init(cfg.Logging.Level, cfg.Logging.Format)
+	InitDatabase(cfg.Database)
+	defer CloseDatabase()
+
+	var in io.Reader = os.Stdin
+	if *file != "" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx = context.WithValue(ctx, CallerContextKey, cliCaller)
+
+	imported, skipped, err := ReadImport(ctx, bufio.NewReader(in), *batchSize)
+	slog.Info("imported messages", "imported", imported, "skipped", skipped)
+	return err
+}
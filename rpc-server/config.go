@@ -0,0 +1,260 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// Config is the configuration of the rpc-server, loaded by configload.Load. See
+// common/configload for the meaning of the struct tags.
+type Config struct {
+	Server     ServerConfig     `yaml:"server"`
+	Discovery  DiscoveryConfig  `yaml:"discovery"`
+	Database   DatabaseConfig   `yaml:"database"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Moderation ModerationConfig `yaml:"moderation"`
+	S2S        S2SConfig        `yaml:"s2s"`
+	Admin      AdminConfig      `yaml:"admin"`
+	Outbox     OutboxConfig     `yaml:"outbox"`
+	Metrics    MetricsConfig    `yaml:"metrics"`
+	Logging    LoggingConfig    `yaml:"logging"`
+	Tracing    TracingConfig    `yaml:"tracing"`
+	Shutdown   ShutdownConfig   `yaml:"shutdown"`
+}
+
+type ServerConfig struct {
+	ServiceName string `yaml:"service_name" env:"RPC_SERVICE_NAME" flag:"service-name" usage:"name the server registers under"`
+	Addr        string `yaml:"addr" env:"RPC_ADDR" flag:"addr" usage:"address to serve RPCs on"`
+	// Address registered for clients to connect to. If empty, it is the listen
+	// address, with an empty or unspecified host replaced by the address the
+	// hostname of the machine resolves to.
+	AdvertiseAddr string `yaml:"advertise_addr" env:"RPC_ADVERTISE_ADDR" flag:"advertise-addr" usage:"address registered for clients to connect to"`
+}
+
+type DiscoveryConfig struct {
+	// One of etcd, static or dns. The server only registers itself with etcd,
+	// with static and dns discovery clients are configured with its address.
+	Type          string   `yaml:"type" env:"DISCOVERY_TYPE" flag:"discovery" usage:"service discovery (etcd, static or dns)"`
+	EtcdEndpoints []string `yaml:"etcd_endpoints" env:"ETCD_ENDPOINTS" flag:"etcd-endpoints" usage:"comma separated etcd endpoints"`
+}
+
+type DatabaseConfig struct {
+	Host            string        `yaml:"host" env:"POSTGRES_HOST"`
+	Port            int           `yaml:"port" env:"POSTGRES_PORT"`
+	User            string        `yaml:"user" env:"POSTGRES_USER"`
+	Password        string        `yaml:"password" env:"POSTGRES_PASSWORD" secret:"true"`
+	Name            string        `yaml:"name" env:"POSTGRES_DB"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+	// One of silent, error, warn or info.
+	LogLevel string `yaml:"log_level" env:"DB_LOG_LEVEL" flag:"db-log-level" usage:"GORM log level (silent, error, warn or info)"`
+}
+
+type RateLimitConfig struct {
+	// One of off, memory, postgres or redis.
+	Backend       string  `yaml:"backend" env:"RATE_LIMIT_BACKEND"`
+	SenderRate    float64 `yaml:"sender_rate" env:"RATE_LIMIT_SENDER_RATE"`
+	SenderBurst   float64 `yaml:"sender_burst" env:"RATE_LIMIT_SENDER_BURST"`
+	ChatRate      float64 `yaml:"chat_rate" env:"RATE_LIMIT_CHAT_RATE"`
+	ChatBurst     float64 `yaml:"chat_burst" env:"RATE_LIMIT_CHAT_BURST"`
+	RedisAddr     string  `yaml:"redis_addr" env:"REDIS_ADDR"`
+	RedisPassword string  `yaml:"redis_password" env:"REDIS_PASSWORD" secret:"true"`
+}
+
+type ModerationConfig struct {
+	// Zero disables the size limit, which also applies to encrypted messages.
+	MaxBytes int `yaml:"max_bytes" env:"MODERATION_MAX_BYTES"`
+	// Zero disables the length limit.
+	MaxLength         int    `yaml:"max_length" env:"MODERATION_MAX_LENGTH"`
+	BannedWordsFile   string `yaml:"banned_words_file" env:"MODERATION_BANNED_WORDS_FILE"`
+	BannedWordsAction string `yaml:"banned_words_action" env:"MODERATION_BANNED_WORDS_ACTION"`
+	LinksAction       string `yaml:"links_action" env:"MODERATION_LINKS_ACTION"`
+}
+
+type S2SConfig struct {
+	// Caller verification is disabled if empty.
+	SharedSecret   string        `yaml:"shared_secret" env:"S2S_SHARED_SECRET" secret:"true"`
+	MaxClockSkew   time.Duration `yaml:"max_clock_skew" env:"S2S_MAX_CLOCK_SKEW"`
+	AllowedCallers []string      `yaml:"allowed_callers" env:"S2S_ALLOWED_CALLERS"`
+}
+
+type AdminConfig struct {
+	Users    []string `yaml:"users" env:"ADMIN_USERS"`
+	Services []string `yaml:"services" env:"ADMIN_SERVICES"`
+}
+
+type OutboxConfig struct {
+	// One of off or file. Events are only written to the outbox if a sink is set.
+	Sink         string        `yaml:"sink" env:"OUTBOX_SINK" flag:"outbox-sink" usage:"sink change events are published to (off or file)"`
+	File         string        `yaml:"file" env:"OUTBOX_FILE"`
+	BatchSize    int           `yaml:"batch_size" env:"OUTBOX_BATCH_SIZE"`
+	PollInterval time.Duration `yaml:"poll_interval" env:"OUTBOX_POLL_INTERVAL"`
+	// Published events are deleted after this long, zero keeps them.
+	Retention time.Duration `yaml:"retention" env:"OUTBOX_RETENTION"`
+}
+
+type MetricsConfig struct {
+	Addr string `yaml:"addr" env:"METRICS_ADDR" flag:"metrics-addr" usage:"address to serve metrics and health checks on"`
+}
+
+type LoggingConfig struct {
+	// One of debug, info, warn or error.
+	Level string `yaml:"level" env:"LOG_LEVEL" flag:"log-level" usage:"minimum log level (debug, info, warn or error)"`
+	// One of json or text.
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+}
+
+type TracingConfig struct {
+	// One of none, otlp or stdout.
+	Exporter string `yaml:"exporter" env:"OTEL_TRACES_EXPORTER"`
+}
+
+// DefaultConfig returns the configuration used when nothing is overridden.
+func DefaultConfig() *Config {
+	return &Config{
+		Server: ServerConfig{
+			ServiceName: "demo.rpc.server",
+			Addr:        ":8888",
+		},
+		Discovery: DiscoveryConfig{
+			Type:          "etcd",
+			EtcdEndpoints: []string{"etcd:2379"},
+		},
+		Database: DatabaseConfig{
+			Host:            "db",
+			Port:            5432,
+			User:            "imservice",
+			Password:        "password",
+			Name:            "imservice",
+			MaxIdleConns:    10,
+			MaxOpenConns:    50,
+			ConnMaxLifetime: 15 * time.Minute,
+			LogLevel:        "silent",
+		},
+		RateLimit: RateLimitConfig{
+			Backend:     "off",
+			SenderRate:  5,
+			SenderBurst: 20,
+			ChatRate:    20,
+			ChatBurst:   50,
+			RedisAddr:   "redis:6379",
+		},
+		Moderation: ModerationConfig{
+			MaxBytes:          64 * 1024,
+			BannedWordsAction: "mask",
+			LinksAction:       "allow",
+		},
+		S2S: S2SConfig{
+			MaxClockSkew: 30 * time.Second,
+		},
+		Outbox: OutboxConfig{
+			Sink:         "off",
+			File:         "outbox.jsonl",
+			BatchSize:    100,
+			PollInterval: time.Second,
+			Retention:    24 * time.Hour,
+		},
+		Metrics: MetricsConfig{
+			Addr: ":9090",
+		},
+		Logging: LoggingConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		Tracing: TracingConfig{
+			Exporter: "none",
+		},
+		Shutdown: ShutdownConfig{
+			Delay:   3 * time.Second,
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// Validate reports the first invalid setting in the configuration.
+func (c *Config) Validate() error {
+	if c.Server.ServiceName == "" {
+		return errors.New("server.service_name is required")
+	}
+	if _, _, err := net.SplitHostPort(c.Server.Addr); err != nil {
+		return fmt.Errorf("server.addr: %w", err)
+	}
+	if c.Server.AdvertiseAddr != "" {
+		if _, _, err := net.SplitHostPort(c.Server.AdvertiseAddr); err != nil {
+			return fmt.Errorf("server.advertise_addr: %w", err)
+		}
+	}
+	if !Contains([]string{"etcd", "static", "dns"}, c.Discovery.Type) {
+		return fmt.Errorf("discovery.type: unknown discovery %s", c.Discovery.Type)
+	}
+	if c.Discovery.Type == "etcd" && len(c.Discovery.EtcdEndpoints) == 0 {
+		return errors.New("discovery.etcd_endpoints is required for etcd discovery")
+	}
+
+	if c.Database.Port <= 0 || c.Database.Port > 65535 {
+		return fmt.Errorf("database.port: invalid port %d", c.Database.Port)
+	}
+	if c.Database.MaxOpenConns < 1 || c.Database.MaxIdleConns < 0 {
+		return errors.New("database.max_open_conns must be positive and database.max_idle_conns not negative")
+	}
+	if !Contains([]string{"silent", "error", "warn", "info"}, strings.ToLower(c.Database.LogLevel)) {
+		return fmt.Errorf("database.log_level: unknown level %s", c.Database.LogLevel)
+	}
+
+	if !Contains([]string{"off", "memory", "postgres", "redis"}, c.RateLimit.Backend) {
+		return fmt.Errorf("rate_limit.backend: unknown backend %s", c.RateLimit.Backend)
+	}
+	// A rate of 0 disables the limit, whose burst is then unused
+	if c.RateLimit.SenderRate < 0 || c.RateLimit.ChatRate < 0 {
+		return errors.New("rate_limit: rates must not be negative")
+	}
+	if (c.RateLimit.SenderRate > 0 && c.RateLimit.SenderBurst < 1) || (c.RateLimit.ChatRate > 0 && c.RateLimit.ChatBurst < 1) {
+		return errors.New("rate_limit: bursts of enabled limits must be at least 1")
+	}
+
+	if c.Moderation.MaxBytes < 0 || c.Moderation.MaxLength < 0 {
+		return errors.New("moderation.max_bytes and moderation.max_length must not be negative")
+	}
+	if _, err := ParseModerationAction(c.Moderation.BannedWordsAction); err != nil {
+		return fmt.Errorf("moderation.banned_words_action: %w", err)
+	}
+	if _, err := ParseModerationAction(c.Moderation.LinksAction); err != nil {
+		return fmt.Errorf("moderation.links_action: %w", err)
+	}
+
+	if !Contains([]string{"off", "file"}, c.Outbox.Sink) {
+		return fmt.Errorf("outbox.sink: unknown sink %s", c.Outbox.Sink)
+	}
+	if c.Outbox.Sink == "file" && c.Outbox.File == "" {
+		return errors.New("outbox.file is required for the file sink")
+	}
+	if c.Outbox.BatchSize < 1 || c.Outbox.PollInterval <= 0 || c.Outbox.Retention < 0 {
+		return errors.New("outbox: batch_size must be at least 1, poll_interval positive and retention not negative")
+	}
+
+	if c.S2S.MaxClockSkew <= 0 {
+		return errors.New("s2s.max_clock_skew must be positive")
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Logging.Level)); err != nil {
+		return fmt.Errorf("logging.level: %w", err)
+	}
+	if !Contains([]string{"json", "text"}, strings.ToLower(c.Logging.Format)) {
+		return fmt.Errorf("logging.format: unknown format %s", c.Logging.Format)
+	}
+	if !Contains([]string{"none", "otlp", "stdout"}, strings.ToLower(c.Tracing.Exporter)) {
+		return fmt.Errorf("tracing.exporter: unknown exporter %s", c.Tracing.Exporter)
+	}
+
+	if c.Shutdown.Delay < 0 || c.Shutdown.Timeout <= 0 {
+		return errors.New("shutdown.delay must not be negative and shutdown.timeout must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/common/configload"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte(`
+server:
+  addr: ":9999"
+discovery:
+  etcd_endpoints: ["etcd-a:2379", "etcd-b:2379"]
+database:
+  host: postgres.internal
+  max_open_conns: 100
+rate_limit:
+  backend: memory
+shutdown:
+  timeout: 30s
+`), 0o600)
+	if err != nil {
+		t.Fatalf("Error when writing config file: %+v\n", err)
+	}
+
+	t.Setenv("POSTGRES_HOST", "postgres.env")
+	t.Setenv("RATE_LIMIT_BACKEND", "redis")
+	t.Setenv("ADMIN_USERS", "alice, bob")
+
+	cfg := DefaultConfig()
+	printed, err := configload.Load(cfg, []string{"rpc-server", "--config", path, "--etcd-endpoints", "etcd-flag:2379"}, nil)
+	assert.Nil(t, err)
+	assert.False(t, printed)
+
+	// Defaults are kept unless overridden
+	assert.Equal(t, "demo.rpc.server", cfg.Server.ServiceName)
+	assert.Equal(t, 10, cfg.Database.MaxIdleConns)
+	// The file overrides the defaults
+	assert.Equal(t, ":9999", cfg.Server.Addr)
+	assert.Equal(t, 100, cfg.Database.MaxOpenConns)
+	assert.Equal(t, 30*time.Second, cfg.Shutdown.Timeout)
+	// The environment overrides the file
+	assert.Equal(t, "postgres.env", cfg.Database.Host)
+	assert.Equal(t, "redis", cfg.RateLimit.Backend)
+	assert.Equal(t, []string{"alice", "bob"}, cfg.Admin.Users)
+	// Flags override everything
+	assert.Equal(t, []string{"etcd-flag:2379"}, cfg.Discovery.EtcdEndpoints)
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	t.Setenv("RATE_LIMIT_BACKEND", "carrier-pigeon")
+	_, err := configload.Load(DefaultConfig(), []string{"rpc-server"}, nil)
+	assert.NotNil(t, err)
+
+	t.Setenv("RATE_LIMIT_BACKEND", "")
+	t.Setenv("SHUTDOWN_TIMEOUT", "soon")
+	_, err = configload.Load(DefaultConfig(), []string{"rpc-server"}, nil)
+	assert.NotNil(t, err)
+
+	t.Setenv("SHUTDOWN_TIMEOUT", "")
+	_, err = configload.Load(DefaultConfig(), []string{"rpc-server", "--log-level", "loud"}, nil)
+	assert.NotNil(t, err)
+
+	_, err = configload.Load(DefaultConfig(), []string{"rpc-server", "--outbox-sink", "carrier-pigeon"}, nil)
+	assert.NotNil(t, err)
+}
+
+func TestLoadConfig_RateLimits(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   map[string]string
+		valid bool
+	}{
+		{name: "defaults", valid: true},
+		{name: "sender limit disabled", env: map[string]string{"RATE_LIMIT_SENDER_RATE": "0", "RATE_LIMIT_SENDER_BURST": "0"}, valid: true},
+		{name: "chat limit disabled", env: map[string]string{"RATE_LIMIT_CHAT_RATE": "0", "RATE_LIMIT_CHAT_BURST": "0"}, valid: true},
+		{name: "negative rate", env: map[string]string{"RATE_LIMIT_SENDER_RATE": "-1"}},
+		{name: "enabled limit without burst", env: map[string]string{"RATE_LIMIT_CHAT_BURST": "0.5"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+			_, err := configload.Load(DefaultConfig(), []string{"rpc-server"}, nil)
+			assert.Equal(t, test.valid, err == nil, "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestLoadConfig_PrintConfig(t *testing.T) {
+	t.Setenv("S2S_SHARED_SECRET", "hunter2")
+
+	var out bytes.Buffer
+	printed, err := configload.Load(DefaultConfig(), []string{"rpc-server", "--print-config"}, &out)
+	assert.Nil(t, err)
+	assert.True(t, printed)
+	assert.Contains(t, out.String(), "shared_secret: REDACTED")
+	assert.Contains(t, out.String(), "max_clock_skew: 30s")
+	assert.NotContains(t, out.String(), "hunter2")
+}
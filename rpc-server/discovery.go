@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/cloudwego/kitex/pkg/registry"
+	etcd "github.com/kitex-contrib/registry-etcd"
+)
+
+// NewRegistryFromConfig returns the registry the server registers itself with,
+// which does nothing unless discovery is through etcd.
+func NewRegistryFromConfig(cfg DiscoveryConfig) (registry.Registry, error) {
+	if cfg.Type != "etcd" {
+		return registry.NoopRegistry, nil
+	}
+	return etcd.NewEtcdRegistry(cfg.EtcdEndpoints) // r should not be reused.
+}
+
+// ResolveAdvertiseAddr returns the address clients should connect to. An
+// address derived from the listen address must not be a loopback or unspecified
+// address when registering with etcd, as clients on other machines could not
+// reach it; set the advertise address explicitly to run against a local etcd.
+func ResolveAdvertiseAddr(server ServerConfig, discovery DiscoveryConfig) (*net.TCPAddr, error) {
+	if server.AdvertiseAddr != "" {
+		return net.ResolveTCPAddr("tcp", server.AdvertiseAddr)
+	}
+
+	host, port, err := net.SplitHostPort(server.Addr)
+	if err != nil {
+		return nil, err
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		if host, err = os.Hostname(); err != nil {
+			return nil, fmt.Errorf("error acquiring hostname information: %w", err)
+		}
+	}
+
+	addr, err := net.ResolveTCPAddr("tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return nil, fmt.Errorf("error resolving service address: %w", err)
+	}
+	if discovery.Type == "etcd" {
+		if addr.IP.IsLoopback() {
+			return nil, fmt.Errorf("service address %s is a loopback address, set server.advertise_addr", addr)
+		} else if addr.IP.IsUnspecified() {
+			return nil, fmt.Errorf("service address %s is unspecified, set server.advertise_addr", addr)
+		}
+	}
+	return addr, nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"net"
+	"testing"
+
+	"github.com/cloudwego/kitex/pkg/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveAdvertiseAddr(t *testing.T) {
+	addr, err := ResolveAdvertiseAddr(ServerConfig{Addr: ":8888", AdvertiseAddr: "127.0.0.1:9999"}, DiscoveryConfig{Type: "etcd"})
+	assert.Nil(t, err)
+	assert.Equal(t, "127.0.0.1:9999", addr.String())
+
+	// A loopback address is only rejected when it would be registered
+	_, err = ResolveAdvertiseAddr(ServerConfig{Addr: "127.0.0.1:8888"}, DiscoveryConfig{Type: "etcd"})
+	assert.NotNil(t, err)
+	addr, err = ResolveAdvertiseAddr(ServerConfig{Addr: "127.0.0.1:8888"}, DiscoveryConfig{Type: "static"})
+	assert.Nil(t, err)
+	assert.Equal(t, "127.0.0.1:8888", addr.String())
+}
+
+type recordingRegistry struct {
+	registered *registry.Info
+}
+
+func (r *recordingRegistry) Register(info *registry.Info) error {
+	r.registered = info
+	return nil
+}
+
+func (r *recordingRegistry) Deregister(info *registry.Info) error {
+	return nil
+}
+
+func TestDrainingRegistry_AdvertiseAddr(t *testing.T) {
+	inner := &recordingRegistry{}
+	advertiseAddr := &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 8888}
+	r := &DrainingRegistry{Registry: inner, AdvertiseAddr: advertiseAddr}
+
+	info := &registry.Info{ServiceName: "demo.rpc.server", Addr: &net.TCPAddr{IP: net.IPv6unspecified, Port: 8888}}
+	assert.Nil(t, r.Register(info))
+	assert.Equal(t, advertiseAddr, inner.registered.Addr)
+	assert.Equal(t, "demo.rpc.server", inner.registered.ServiceName)
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc"
+	"gorm.io/gorm"
+)
+
+var (
+	invalidErasureModeErr = errors.New("invalid erasure mode")
+	erasureIncompleteErr  = errors.New("rows referring to the user remain after erasure")
+)
+
+// redactedText replaces the text of the messages of an erased user in mode
+// REDACT. Messages must have text, so it is not empty, for an export of an
+// erased chat to be imported like any other.
+const redactedText = "[redacted]"
+
+// newPseudonym returns a random user to replace an erased user with. It is not
+// derived from the user, so erased chats cannot be traced back to them.
+func newPseudonym() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "erased_" + hex.EncodeToString(b), nil
+}
+
+// eraseUser erases user from every chat they are in, along with the flags, keys,
+// blocks, rate limits and outbox events they left, in a single transaction. The
+// erasure is only committed if no rows referring to the user remain, and is
+// recorded in the audit log without the pseudonym, which would identify the
+// erased chats.
+func eraseUser(ctx context.Context, user string, mode rpc.ErasureMode) (*rpc.ErasureReport, error) {
+	pseudonym, err := newPseudonym()
+	if err != nil {
+		return nil, err
+	}
+
+	var report *rpc.ErasureReport
+	err = GetDatabase().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		report = &rpc.ErasureReport{User: user, Pseudonym: pseudonym, Mode: mode, Chats: []*rpc.ErasedChat{}}
+
+		chats, err := findUserChats(tx, user)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Where("sender = ?", user).Delete(&FlaggedMessage{})
+		if result.Error != nil {
+			return result.Error
+		}
+		report.FlagsDeleted = result.RowsAffected
+
+		for _, chatId := range chats {
+			chat, err := eraseChat(tx, chatId, user, pseudonym, mode)
+			if err != nil {
+				return err
+			}
+			report.Chats = append(report.Chats, chat)
+
+			deleted, err := eraseChatEvents(tx, chat, user, pseudonym)
+			if err != nil {
+				return err
+			}
+			report.OutboxEventsDeleted += deleted
+		}
+
+		result = tx.Where("user_id = ?", user).Delete(&UserIdentityKey{})
+		if result.Error != nil {
+			return result.Error
+		}
+		report.KeysDeleted = result.RowsAffected
+
+		result = tx.Where("blocker = ? OR blocked = ?", user, user).Delete(&UserBlock{})
+		if result.Error != nil {
+			return result.Error
+		}
+		report.BlocksDeleted = result.RowsAffected
+
+		result = tx.Where("key IN ?", userRateLimitKeys(user, chats)).Delete(&RateLimitBucket{})
+		if result.Error != nil {
+			return result.Error
+		}
+		report.RateLimitsDeleted = result.RowsAffected
+
+		report.RemainingReferences, err = countUserReferences(tx, user, chats)
+		if err != nil {
+			return err
+		} else if report.RemainingReferences > 0 {
+			return erasureIncompleteErr
+		}
+
+		entry := &AuditLog{
+			Action:     AuditActionErase,
+			TargetUser: user,
+			Details:    erasureDetails(report),
+		}
+		if err := RecordAudit(ctx, tx, entry); err != nil {
+			return err
+		}
+		report.AuditId = int64(entry.ID)
+		report.ErasedAt = int64(entry.CreatedAt)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// findUserChats returns the normalised chats user sent or received messages in,
+// or raised moderation flags in, in order.
+func findUserChats(tx *gorm.DB, user string) ([]string, error) {
+	var messageChats, flagChats []string
+	if err := tx.Model(&ChatMessage{}).Where("sender = ? OR receiver = ?", user, user).Distinct().Pluck("chat_id", &messageChats).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Model(&FlaggedMessage{}).Where("sender = ?", user).Distinct().Pluck("chat_id", &flagChats).Error; err != nil {
+		return nil, err
+	}
+
+	var chats []string
+	for _, chatId := range append(messageChats, flagChats...) {
+		if !Contains(chats, chatId) {
+			chats = append(chats, chatId)
+		}
+	}
+	sort.Strings(chats)
+	return chats, nil
+}
+
+// eraseChat replaces user by pseudonym in a chat, redacting or deleting the
+// messages they sent, and purges the cursors cached for it.
+func eraseChat(tx *gorm.DB, chatId string, user string, pseudonym string, mode rpc.ErasureMode) (*rpc.ErasedChat, error) {
+	members := strings.Split(chatId, ":")
+	for i, member := range members {
+		if member == user {
+			members[i] = pseudonym
+		}
+	}
+	erasedChatId := GetNormalisedChatID(strings.Join(members, ":"))
+	chat := &rpc.ErasedChat{Chat: chatId, ErasedChat: erasedChatId}
+
+	// The receiver of the messages the user sent, which is the user themselves
+	// in a chat with themselves
+	_, receiver := GetSenderReceiver(&rpc.Message{Chat: chatId, Sender: user})
+	if receiver == user {
+		receiver = pseudonym
+	}
+
+	sent := tx.Model(&ChatMessage{}).Where("chat_id = ? AND sender = ?", chatId, user)
+	if mode == rpc.ErasureMode_DELETE {
+		result := sent.Delete(&ChatMessage{})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		chat.MessagesDeleted = result.RowsAffected
+	} else {
+		result := sent.Updates(map[string]interface{}{
+			"chat_id":          erasedChatId,
+			"sender":           pseudonym,
+			"receiver":         receiver,
+			"text":             redactedText,
+			"encrypted":        false,
+			"sender_key_id":    "",
+			"recipient_key_id": "",
+		})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		chat.MessagesRedacted = result.RowsAffected
+	}
+
+	result := tx.Model(&ChatMessage{}).Where("chat_id = ? AND receiver = ?", chatId, user).Updates(map[string]interface{}{
+		"chat_id":  erasedChatId,
+		"receiver": pseudonym,
+	})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	chat.MessagesAnonymised = result.RowsAffected
+
+	// Flags raised on the other member's messages remain, in the erased chat
+	if err := tx.Model(&FlaggedMessage{}).Where("chat_id = ?", chatId).Update("chat_id", erasedChatId).Error; err != nil {
+		return nil, err
+	}
+
+	result = tx.Where("chat_id = ?", chatId).Delete(&ChatCursorCache{})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	chat.CursorsPurged = result.RowsAffected
+	return chat, nil
+}
+
+// eraseChatEvents erases user from the outbox events of an erased chat,
+// returning the number of events deleted. Events already published, and those
+// of the messages the user sent, are deleted. Events of the other member's
+// messages, which remain in the erased chat, are still to be published to
+// consumers, so they are rewritten to refer to the erased chat and pseudonym.
+func eraseChatEvents(tx *gorm.DB, chat *rpc.ErasedChat, user string, pseudonym string) (int64, error) {
+	var events []*OutboxEvent
+	if err := tx.Where("key = ?", chat.Chat).Find(&events).Error; err != nil {
+		return 0, err
+	}
+
+	var deleted []uint64
+	for _, event := range events {
+		var payload MessageCreated
+		if event.PublishedAt != 0 || event.Type != EventTypeMessageCreated {
+			deleted = append(deleted, event.ID)
+			continue
+		} else if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return 0, err
+		} else if payload.Sender == user {
+			deleted = append(deleted, event.ID)
+			continue
+		}
+
+		payload.Chat = chat.ErasedChat
+		if payload.Receiver == user {
+			payload.Receiver = pseudonym
+		}
+		rewritten, err := json.Marshal(&payload)
+		if err != nil {
+			return 0, err
+		}
+		if err := tx.Model(event).Updates(map[string]interface{}{"key": chat.ErasedChat, "payload": string(rewritten)}).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	if len(deleted) == 0 {
+		return 0, nil
+	}
+	result := tx.Where("id IN ?", deleted).Delete(&OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
+
+// userRateLimitKeys returns the keys of the rate limit buckets of user and of
+// their chats.
+func userRateLimitKeys(user string, chats []string) []string {
+	keys := []string{"sender:" + user}
+	for _, chatId := range chats {
+		keys = append(keys, "chat:"+chatId)
+	}
+	return keys
+}
+
+// countUserReferences counts the rows still referring to user, by their user
+// ID or by one of their chats, other than in the audit log.
+func countUserReferences(tx *gorm.DB, user string, chats []string) (int64, error) {
+	queries := []*gorm.DB{
+		tx.Model(&ChatMessage{}).Where("sender = ? OR receiver = ? OR chat_id IN ?", user, user, chats),
+		tx.Model(&FlaggedMessage{}).Where("sender = ? OR chat_id IN ?", user, chats),
+		tx.Model(&ChatCursorCache{}).Where("chat_id IN ?", chats),
+		tx.Model(&UserIdentityKey{}).Where("user_id = ?", user),
+		tx.Model(&UserBlock{}).Where("blocker = ? OR blocked = ?", user, user),
+		tx.Model(&RateLimitBucket{}).Where("key IN ?", userRateLimitKeys(user, chats)),
+		tx.Model(&OutboxEvent{}).Where("key IN ?", chats),
+	}
+
+	var total int64
+	for _, query := range queries {
+		var count int64
+		if err := query.Count(&count).Error; err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// erasureDetails summarises a report for the audit log.
+func erasureDetails(report *rpc.ErasureReport) string {
+	var redacted, deleted, anonymised, cursors int64
+	for _, chat := range report.Chats {
+		redacted += chat.MessagesRedacted
+		deleted += chat.MessagesDeleted
+		anonymised += chat.MessagesAnonymised
+		cursors += chat.CursorsPurged
+	}
+	return fmt.Sprintf(
+		"mode=%s chats=%d messages_redacted=%d messages_deleted=%d messages_anonymised=%d cursors_purged=%d flags_deleted=%d keys_deleted=%d blocks_deleted=%d rate_limits_deleted=%d outbox_events_deleted=%d",
+		report.Mode, len(report.Chats), redacted, deleted, anonymised, cursors,
+		report.FlagsDeleted, report.KeysDeleted, report.BlocksDeleted, report.RateLimitsDeleted, report.OutboxEventsDeleted,
+	)
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIMServiceImpl_EraseUser_Redact(t *testing.T) {
+	InitAdmin(AdminConfig{Users: []string{"erase_admin"}})
+	defer InitAdmin(AdminConfig{})
+	s := &IMServiceImpl{}
+	ctx := context.Background()
+	adminCtx := context.WithValue(ctx, CallerContextKey, &Caller{Service: "http-server", User: "erase_admin"})
+	db := GetDatabase()
+	outboxEnabled = true
+	defer func() { outboxEnabled = false }()
+
+	for _, message := range []*rpc.Message{
+		{Chat: "erase_a:erase_b", Text: "1", Sender: "erase_a"},
+		{Chat: "erase_a:erase_b", Text: "2", Sender: "erase_b"},
+		{Chat: "erase_a:erase_b", Text: "3", Sender: "erase_a"},
+		{Chat: "erase_c:erase_a", Text: "4", Sender: "erase_c"},
+		{Chat: "erase_a:erase_a", Text: "5", Sender: "erase_a"},
+	} {
+		message.SendTime = GetTimeNow().UnixMicro()
+		if resp, err := s.Send(ctx, &rpc.SendRequest{Message: message}); err != nil || resp.GetCode() != 0 {
+			t.Fatalf("Error when creating test messages for erasure test: %+v, code: %d\n", err, resp.GetCode())
+		}
+	}
+	for _, row := range []interface{}{
+		&FlaggedMessage{ChatID: "erase_a:erase_b", Sender: "erase_a", Text: "1"},
+		&FlaggedMessage{ChatID: "erase_a:erase_b", Sender: "erase_b", Text: "2"},
+		&UserIdentityKey{UserID: "erase_a", KeyID: "k1"},
+		&UserBlock{Blocker: "erase_a", Blocked: "erase_c"},
+		&UserBlock{Blocker: "erase_b", Blocked: "erase_a"},
+		&RateLimitBucket{Key: "sender:erase_a"},
+		&RateLimitBucket{Key: "chat:erase_a:erase_b"},
+	} {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatalf("Error when creating test rows for erasure test: %+v\n", err)
+		}
+	}
+	// The event of the message of the other member of one chat was published
+	if err := db.Model(&OutboxEvent{}).Where("key = ?", "erase_a:erase_c").Update("published_at", 1).Error; err != nil {
+		t.Fatalf("Error when publishing test events for erasure test: %+v\n", err)
+	}
+	// Cache cursors of the chat
+	s.Pull(ctx, &rpc.PullRequest{Chat: "erase_a:erase_b", Limit: 1})
+	s.Pull(ctx, &rpc.PullRequest{Chat: "erase_a:erase_b", Cursor: 1, Limit: 1})
+
+	userCtx := context.WithValue(ctx, CallerContextKey, &Caller{Service: "http-server", User: "erase_a"})
+	resp, err := s.EraseUser(userCtx, &rpc.EraseUserRequest{User: "erase_a", Mode: rpc.ErasureMode_REDACT})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_PERMISSION_DENIED), resp.GetCode())
+
+	resp, err = s.EraseUser(adminCtx, &rpc.EraseUserRequest{User: "erase_a"})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_INVALID_ARGUMENT), resp.GetCode())
+	assert.Equal(t, invalidErasureModeErr.Error(), resp.GetMsg())
+
+	resp, err = s.EraseUser(adminCtx, &rpc.EraseUserRequest{User: "erase_a", Mode: rpc.ErasureMode_REDACT})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), resp.GetCode())
+	report := resp.GetReport()
+	pseudonym := report.GetPseudonym()
+	assert.True(t, strings.HasPrefix(pseudonym, "erased_"))
+	assert.Equal(t, int64(1), report.GetFlagsDeleted())
+	assert.Equal(t, int64(1), report.GetKeysDeleted())
+	assert.Equal(t, int64(2), report.GetBlocksDeleted())
+	assert.Equal(t, int64(2), report.GetRateLimitsDeleted())
+	assert.Equal(t, int64(4), report.GetOutboxEventsDeleted())
+	assert.Equal(t, int64(0), report.GetRemainingReferences())
+	assert.NotZero(t, report.GetAuditId())
+	if assert.Equal(t, 3, len(report.GetChats())) {
+		chat := report.GetChats()[0]
+		assert.Equal(t, "erase_a:erase_a", chat.GetChat())
+		assert.Equal(t, pseudonym+":"+pseudonym, chat.GetErasedChat())
+		assert.Equal(t, int64(1), chat.GetMessagesRedacted())
+
+		chat = report.GetChats()[1]
+		assert.Equal(t, "erase_a:erase_b", chat.GetChat())
+		assert.Equal(t, GetNormalisedChatID(pseudonym+":erase_b"), chat.GetErasedChat())
+		assert.Equal(t, int64(2), chat.GetMessagesRedacted())
+		assert.Equal(t, int64(1), chat.GetMessagesAnonymised())
+		assert.Equal(t, int64(2), chat.GetCursorsPurged())
+
+		chat = report.GetChats()[2]
+		assert.Equal(t, "erase_a:erase_c", chat.GetChat())
+		assert.Equal(t, int64(0), chat.GetMessagesRedacted())
+		assert.Equal(t, int64(1), chat.GetMessagesAnonymised())
+	}
+
+	// The other member still sees the chat, with the user's messages redacted
+	pullResp, err := s.Pull(ctx, &rpc.PullRequest{Chat: pseudonym + ":erase_b", Limit: 10})
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(pullResp.GetMessages())) {
+		assert.Equal(t, pseudonym, pullResp.GetMessages()[0].GetSender())
+		assert.Equal(t, redactedText, pullResp.GetMessages()[0].GetText())
+		assert.Equal(t, "erase_b", pullResp.GetMessages()[1].GetSender())
+		assert.Equal(t, "2", pullResp.GetMessages()[1].GetText())
+	}
+	// The pending event of the other member's message is still to be published,
+	// in the erased chat
+	var events []*OutboxEvent
+	assert.Nil(t, db.Where("payload LIKE ?", "%erase_a%").Find(&events).Error)
+	assert.Empty(t, events)
+	assert.Nil(t, db.Where("key = ?", GetNormalisedChatID(pseudonym+":erase_b")).Find(&events).Error)
+	if assert.Equal(t, 1, len(events)) {
+		assert.Zero(t, events[0].PublishedAt)
+		var payload MessageCreated
+		assert.Nil(t, json.Unmarshal([]byte(events[0].Payload), &payload))
+		assert.Equal(t, GetNormalisedChatID(pseudonym+":erase_b"), payload.Chat)
+		assert.Equal(t, "erase_b", payload.Sender)
+		assert.Equal(t, pseudonym, payload.Receiver)
+		assert.Equal(t, "2", payload.Text)
+	}
+
+	count, err := countUserReferences(db, "erase_a", []string{"erase_a:erase_a", "erase_a:erase_b", "erase_a:erase_c"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+
+	var flag FlaggedMessage
+	assert.Nil(t, db.Where("sender = ?", "erase_b").First(&flag).Error)
+	assert.Equal(t, GetNormalisedChatID(pseudonym+":erase_b"), flag.ChatID)
+
+	// The audit log records the erasure, but not the pseudonym
+	var entry AuditLog
+	assert.Nil(t, db.First(&entry, report.GetAuditId()).Error)
+	assert.Equal(t, AuditActionErase, entry.Action)
+	assert.Equal(t, "erase_a", entry.TargetUser)
+	assert.Contains(t, entry.Details, "mode=REDACT chats=3 messages_redacted=3")
+	assert.NotContains(t, entry.Details, pseudonym)
+}
+
+func TestIMServiceImpl_EraseUser_Delete(t *testing.T) {
+	InitAdmin(AdminConfig{Services: []string{"privacy"}})
+	defer InitAdmin(AdminConfig{})
+	s := &IMServiceImpl{}
+	ctx := context.Background()
+	adminCtx := context.WithValue(ctx, CallerContextKey, &Caller{Service: "privacy"})
+
+	for _, message := range []*rpc.Message{
+		{Chat: "erase_d:erase_e", Text: "1", Sender: "erase_d"},
+		{Chat: "erase_d:erase_e", Text: "2", Sender: "erase_e"},
+		{Chat: "erase_d:erase_e", Text: "3", Sender: "erase_d"},
+	} {
+		message.SendTime = GetTimeNow().UnixMicro()
+		if resp, err := s.Send(ctx, &rpc.SendRequest{Message: message}); err != nil || resp.GetCode() != 0 {
+			t.Fatalf("Error when creating test messages for erasure test: %+v, code: %d\n", err, resp.GetCode())
+		}
+	}
+
+	resp, err := s.EraseUser(adminCtx, &rpc.EraseUserRequest{User: "erase_d", Mode: rpc.ErasureMode_DELETE})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), resp.GetCode())
+	if assert.Equal(t, 1, len(resp.GetReport().GetChats())) {
+		chat := resp.GetReport().GetChats()[0]
+		assert.Equal(t, int64(2), chat.GetMessagesDeleted())
+		assert.Equal(t, int64(0), chat.GetMessagesRedacted())
+		assert.Equal(t, int64(1), chat.GetMessagesAnonymised())
+	}
+
+	pullResp, err := s.Pull(ctx, &rpc.PullRequest{Chat: resp.GetReport().GetChats()[0].GetErasedChat(), Limit: 10})
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(pullResp.GetMessages())) {
+		assert.Equal(t, "2", pullResp.GetMessages()[0].GetText())
+	}
+
+	// Erasing a user again finds nothing left
+	resp, err = s.EraseUser(adminCtx, &rpc.EraseUserRequest{User: "erase_d", Mode: rpc.ErasureMode_DELETE})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), resp.GetCode())
+	assert.Equal(t, 0, len(resp.GetReport().GetChats()))
+}
+
+func TestEraseUser_ExportImport(t *testing.T) {
+	s := &IMServiceImpl{}
+	ctx := context.WithValue(context.Background(), CallerContextKey, cliCaller)
+	for _, message := range []*rpc.Message{
+		{Chat: "eraseexport_a:eraseexport_b", Text: "1", Sender: "eraseexport_a"},
+		{Chat: "eraseexport_a:eraseexport_b", Text: "2", Sender: "eraseexport_b"},
+	} {
+		message.SendTime = GetTimeNow().UnixMicro()
+		if resp, err := s.Send(ctx, &rpc.SendRequest{Message: message}); err != nil || resp.GetCode() != 0 {
+			t.Fatalf("Error when creating test messages for erasure test: %+v, code: %d\n", err, resp.GetCode())
+		}
+	}
+	report, err := eraseUser(ctx, "eraseexport_a", rpc.ErasureMode_REDACT)
+	if err != nil {
+		t.Fatalf("Error when erasing user for erasure test: %+v\n", err)
+	}
+	erasedChat := report.GetChats()[0].GetErasedChat()
+
+	// An export of the erased chat, redacted messages included, can be imported
+	var buf bytes.Buffer
+	written, err := WriteExport(ctx, &buf, erasedChat, "")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, written)
+	if err := GetDatabase().Where("chat_id = ?", erasedChat).Delete(&ChatMessage{}).Error; err != nil {
+		t.Fatalf("Error when deleting messages for erasure test: %+v\n", err)
+	}
+	imported, skipped, err := ReadImport(ctx, &buf, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, imported)
+	assert.Equal(t, 0, skipped)
+
+	resp, err := s.Pull(ctx, &rpc.PullRequest{Chat: erasedChat, Limit: 10})
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(resp.GetMessages())) {
+		assert.Equal(t, report.GetPseudonym(), resp.GetMessages()[0].GetSender())
+		assert.Equal(t, redactedText, resp.GetMessages()[0].GetText())
+		assert.Equal(t, "2", resp.GetMessages()[1].GetText())
+	}
+}
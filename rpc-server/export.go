@@ -0,0 +1,399 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc"
+	"gorm.io/gorm"
+)
+
+const (
+	maxExportMessages = 1000
+	maxImportMessages = 1000
+)
+
+var (
+	exportTargetErr      = errors.New("exactly one of a chat or a user must be exported")
+	invalidImportSizeErr = fmt.Errorf("between 1 and %d messages must be imported", maxImportMessages)
+)
+
+// exportRecord is a message as written to an export, one JSON object per line.
+// The chat and send time are preserved as stored so that importing the export
+// into another deployment reproduces the chat.
+type exportRecord struct {
+	ChatID         string `json:"chat_id"`
+	Sender         string `json:"sender"`
+	Receiver       string `json:"receiver"`
+	Text           string `json:"text"`
+	SentAt         uint64 `json:"sent_at"` // unit: microseconds
+	Encrypted      bool   `json:"encrypted,omitempty"`
+	SenderKeyID    string `json:"sender_key_id,omitempty"`
+	RecipientKeyID string `json:"recipient_key_id,omitempty"`
+}
+
+func newExportRecord(msg *ChatMessage) *exportRecord {
+	return &exportRecord{
+		ChatID:         msg.ChatID,
+		Sender:         msg.Sender,
+		Receiver:       msg.Receiver,
+		Text:           msg.Text,
+		SentAt:         msg.SentAt,
+		Encrypted:      msg.Encrypted,
+		SenderKeyID:    msg.SenderKeyID,
+		RecipientKeyID: msg.RecipientKeyID,
+	}
+}
+
+func (record *exportRecord) ToMessage() *rpc.Message {
+	message := &rpc.Message{
+		Chat:     record.ChatID,
+		Text:     record.Text,
+		Sender:   record.Sender,
+		SendTime: int64(record.SentAt),
+	}
+	if record.Encrypted {
+		message.Encrypted = &record.Encrypted
+		message.SenderKeyId = &record.SenderKeyID
+		message.RecipientKeyId = &record.RecipientKeyID
+	}
+	return message
+}
+
+// ValidateExportTarget checks that exactly one of a chat or a user is given, and
+// that it is valid.
+func ValidateExportTarget(chat string, user string) error {
+	if (chat == "") == (user == "") {
+		return exportTargetErr
+	}
+	if chat != "" {
+		return ValidateChatID(chat)
+	}
+	return ValidateUser(user)
+}
+
+// exportPosition is the position of a message in an export, which is ordered by
+// chat, then by send time.
+type exportPosition struct {
+	chatID string
+	sentAt uint64
+}
+
+func positionOf(msg *ChatMessage) *exportPosition {
+	return &exportPosition{chatID: msg.ChatID, sentAt: msg.SentAt}
+}
+
+// String returns the position as the cursor of a page of an export.
+func (p *exportPosition) String() string {
+	return fmt.Sprintf("%d:%s", p.sentAt, p.chatID)
+}
+
+// parseExportPosition parses the cursor of a page of an export.
+func parseExportPosition(cursor string) (*exportPosition, error) {
+	sentAt, chatID, ok := strings.Cut(cursor, ":")
+	if !ok || ValidateChatID(chatID) != nil {
+		return nil, invalidCursorErr
+	}
+	position := &exportPosition{chatID: chatID}
+	var err error
+	if position.sentAt, err = strconv.ParseUint(sentAt, 10, 64); err != nil {
+		return nil, invalidCursorErr
+	}
+	return position, nil
+}
+
+// exportMessages returns up to limit messages of chat, or of every chat user is
+// a member of, after position, or from the first if it is nil. Messages are
+// ordered by chat, then by send time, both descending if reverse, and
+// paginated by their position rather than an offset so that messages stored
+// during an export do not move the pages after them.
+func exportMessages(ctx context.Context, chat string, user string, reverse bool, after *exportPosition, limit int) ([]*ChatMessage, error) {
+	query := GetDatabase().WithContext(ctx)
+	if chat != "" {
+		query = query.Where("chat_id = ?", GetNormalisedChatID(chat))
+	} else {
+		query = query.Where("(sender = ? OR receiver = ?)", user, user)
+	}
+	sortType, sortCond := "ASC", ">"
+	if reverse {
+		sortType, sortCond = "DESC", "<"
+	}
+	if after != nil {
+		query = query.Where(fmt.Sprintf("(chat_id %[1]s ? OR (chat_id = ? AND sent_at %[1]s ?))", sortCond), after.chatID, after.chatID, after.sentAt)
+	}
+
+	var messages []*ChatMessage
+	defer observeQuery("messages_export", time.Now())
+	err := query.Order(fmt.Sprintf("chat_id %[1]s, sent_at %[1]s", sortType)).Limit(limit).Find(&messages).Error
+	return messages, err
+}
+
+// exportPage returns a page of up to limit messages of an export after position,
+// as exportMessages, and whether there are more. Messages of a chat sent at the
+// same time are never split across pages, as their position is the same, so a
+// page is shorter than limit if it would end among them, or longer in the
+// unlikely case that more than limit of them were sent at once.
+func exportPage(ctx context.Context, chat string, user string, reverse bool, after *exportPosition, limit int) ([]*ChatMessage, bool, error) {
+	messages, err := exportMessages(ctx, chat, user, reverse, after, limit+1)
+	if err != nil || len(messages) <= limit {
+		return messages, false, err
+	}
+
+	next := positionOf(messages[limit])
+	page := messages[:limit]
+	for len(page) > 0 && *positionOf(page[len(page)-1]) == *next {
+		page = page[:len(page)-1]
+	}
+	if len(page) > 0 {
+		return page, true, nil
+	}
+
+	start := time.Now()
+	err = GetDatabase().WithContext(ctx).Where("chat_id = ? AND sent_at = ?", next.chatID, next.sentAt).Find(&page).Error
+	observeQuery("messages_export", start)
+	if err != nil {
+		return nil, false, err
+	}
+	more, err := exportMessages(ctx, chat, user, reverse, next, 1)
+	return page, len(more) > 0, err
+}
+
+// mayExport reports whether the caller may export chat, or every chat of user.
+// Administrators may export any chat, and users the chats they are members of.
+func mayExport(ctx context.Context, chat string, user string) bool {
+	if IsAdmin(ctx) {
+		return true
+	}
+	caller := GetCallerUser(ctx)
+	if caller == "" {
+		return false
+	}
+	if chat != "" {
+		return IsChatMember(GetNormalisedChatID(chat), caller)
+	}
+	return user == caller
+}
+
+// recordExport records the export of chat, or of every chat of user, in the
+// audit log.
+func recordExport(ctx context.Context, chat string, user string) error {
+	entry := &AuditLog{Action: AuditActionExport, TargetUser: user}
+	if chat != "" {
+		entry.TargetChat = GetNormalisedChatID(chat)
+	}
+	return RecordAudit(ctx, GetDatabase().WithContext(ctx), entry)
+}
+
+// WriteExport writes every message of chat, or of every chat user is a member
+// of, to w as JSON Lines, a page at a time, returning the number of messages
+// written. The export is recorded in the audit log.
+func WriteExport(ctx context.Context, w io.Writer, chat string, user string) (int, error) {
+	if err := recordExport(ctx, chat, user); err != nil {
+		return 0, err
+	}
+
+	encoder := json.NewEncoder(w)
+	written := 0
+	var after *exportPosition
+	for {
+		messages, hasMore, err := exportPage(ctx, chat, user, false, after, maxExportMessages)
+		if err != nil {
+			return written, err
+		}
+		for _, msg := range messages {
+			if err := encoder.Encode(newExportRecord(msg)); err != nil {
+				return written, err
+			}
+			written++
+		}
+		if !hasMore {
+			return written, nil
+		}
+		after = positionOf(messages[len(messages)-1])
+	}
+}
+
+// ReadImport imports the messages of a JSON Lines export read from r, in
+// batches of batchSize, returning the number of messages imported and skipped
+// as duplicates. Batches imported before an error remain imported, and the
+// import can be repeated as messages already stored are skipped.
+func ReadImport(ctx context.Context, r io.Reader, batchSize int) (int, int, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	imported, skipped := 0, 0
+	var batch []*ChatMessage
+	flush := func() error {
+		batchImported, batchSkipped, err := importMessages(ctx, batch)
+		imported, skipped = imported+batchImported, skipped+batchSkipped
+		batch = batch[:0]
+		return err
+	}
+	for line := 1; ; line++ {
+		record := new(exportRecord)
+		if err := decoder.Decode(record); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return imported, skipped, fmt.Errorf("record %d: %w", line, err)
+		}
+
+		msg, err := newImportedMessage(record.ToMessage())
+		if err != nil {
+			return imported, skipped, fmt.Errorf("record %d: %w", line, err)
+		}
+		batch = append(batch, msg)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return imported, skipped, err
+			}
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return imported, skipped, err
+		}
+	}
+	return imported, skipped, nil
+}
+
+// newImportedMessage validates a message to be imported, returning it as it is
+// to be stored. Unlike sent messages, imported messages keep their send time and
+// do not go through blocking, rate limiting or moderation.
+func newImportedMessage(message *rpc.Message) (*ChatMessage, error) {
+	if err := ValidateMessage(message); err != nil {
+		return nil, err
+	}
+	if message.GetSendTime() == 0 {
+		return nil, invalidSendTime
+	}
+
+	sender, receiver := GetSenderReceiver(message)
+	chatMessage := &ChatMessage{
+		ChatID:   GetNormalisedChatIDFromMessage(message),
+		Sender:   sender,
+		Receiver: receiver,
+		Text:     message.GetText(),
+		SentAt:   uint64(message.GetSendTime()),
+	}
+	if message.GetEncrypted() {
+		chatMessage.Encrypted = true
+		chatMessage.SenderKeyID = message.GetSenderKeyId()
+		chatMessage.RecipientKeyID = message.GetRecipientKeyId()
+	}
+	return chatMessage, nil
+}
+
+// importLockClass is the first key of the Postgres advisory locks held by
+// imports, one per chat with its hash as the second key, so that concurrent
+// imports of a chat do not both store a message neither has seen stored.
+const importLockClass = 0x696d7074
+
+// importKey identifies a message within a chat for deduplicating imports, as
+// messages have no IDs of their own.
+type importKey struct {
+	sender string
+	sentAt uint64
+	text   string
+}
+
+// importMessages stores messages in a single transaction, skipping those already
+// stored or repeated, along with their message.created events, and records the
+// import in the audit log. It returns the
+// number of messages stored and skipped.
+func importMessages(ctx context.Context, messages []*ChatMessage) (int, int, error) {
+	var chats []string
+	byChat := make(map[string][]*ChatMessage)
+	for _, msg := range messages {
+		if _, ok := byChat[msg.ChatID]; !ok {
+			chats = append(chats, msg.ChatID)
+		}
+		byChat[msg.ChatID] = append(byChat[msg.ChatID], msg)
+	}
+
+	var imported []*ChatMessage
+	err := GetDatabase().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		imported = nil
+		if tx.Dialector.Name() == "postgres" {
+			// Locked in order so that imports of the same chats cannot deadlock
+			locked := append([]string(nil), chats...)
+			sort.Strings(locked)
+			for _, chatId := range locked {
+				if err := tx.Exec("SELECT pg_advisory_xact_lock(?, hashtext(?))", importLockClass, chatId).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, chatId := range chats {
+			chatMessages := byChat[chatId]
+			sentAts := make([]uint64, len(chatMessages))
+			for i, msg := range chatMessages {
+				sentAts[i] = msg.SentAt
+			}
+
+			var stored []*ChatMessage
+			if err := tx.Where("chat_id = ? AND sent_at IN ?", chatId, sentAts).Find(&stored).Error; err != nil {
+				return err
+			}
+			seen := make(map[importKey]bool, len(stored))
+			for _, msg := range stored {
+				seen[importKey{msg.Sender, msg.SentAt, msg.Text}] = true
+			}
+
+			count := len(imported)
+			for _, msg := range chatMessages {
+				key := importKey{msg.Sender, msg.SentAt, msg.Text}
+				if !seen[key] {
+					seen[key] = true
+					imported = append(imported, msg)
+				}
+			}
+
+			// Imported messages may precede those already stored, moving the
+			// positions cached in either direction
+			if len(imported) > count {
+				if err := tx.Where("chat_id = ?", chatId).Delete(&ChatCursorCache{}).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(imported) > 0 {
+			if err := tx.Create(imported).Error; err != nil {
+				return err
+			}
+			// Consumers such as search index imported messages as they do sent
+			// ones, and can tell them apart by their send times
+			if outboxEnabled {
+				events, err := newMessageCreatedEvents(imported)
+				if err != nil {
+					return err
+				}
+				if err := tx.Create(events).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		entry := &AuditLog{
+			Action:  AuditActionImport,
+			Details: fmt.Sprintf("imported %d messages, skipped %d duplicates", len(imported), len(messages)-len(imported)),
+		}
+		if len(chats) == 1 {
+			entry.TargetChat = chats[0]
+		}
+		return RecordAudit(ctx, tx, entry)
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return len(imported), len(messages) - len(imported), nil
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIMServiceImpl_ExportMessages(t *testing.T) {
+	InitAdmin(AdminConfig{Users: []string{"export_admin"}})
+	defer InitAdmin(AdminConfig{})
+	s := &IMServiceImpl{}
+	ctx := context.Background()
+	userCtx := context.WithValue(ctx, CallerContextKey, &Caller{Service: "http-server", User: "export_a"})
+	adminCtx := context.WithValue(ctx, CallerContextKey, &Caller{Service: "http-server", User: "export_admin"})
+
+	for i, chat := range []string{"export_b:export_a", "export_c:export_a", "export_a:export_b", "export_b:export_c"} {
+		resp, err := s.Send(ctx, &rpc.SendRequest{Message: &rpc.Message{
+			Chat:     chat,
+			Text:     fmt.Sprintf("%d", i),
+			Sender:   strings.Split(chat, ":")[0],
+			SendTime: GetTimeNow().UnixMicro(),
+		}})
+		if err != nil || resp.GetCode() != 0 {
+			t.Fatalf("Error when creating test messages for export test: %+v, code: %d\n", err, resp.GetCode())
+		}
+	}
+
+	// Users may only export their own chats
+	resp, err := s.ExportMessages(userCtx, &rpc.ExportMessagesRequest{Chat: strPtr("export_b:export_c")})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_PERMISSION_DENIED), resp.GetCode())
+	resp, err = s.ExportMessages(userCtx, &rpc.ExportMessagesRequest{User: strPtr("export_b")})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_PERMISSION_DENIED), resp.GetCode())
+	resp, err = s.ExportMessages(ctx, &rpc.ExportMessagesRequest{Chat: strPtr("export_a:export_b")})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_PERMISSION_DENIED), resp.GetCode(), "expected unverified caller to be denied")
+	resp, err = s.ExportMessages(userCtx, &rpc.ExportMessagesRequest{Chat: strPtr("export_b:export_a")})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), resp.GetCode())
+	assert.Equal(t, 2, len(resp.GetMessages()))
+
+	resp, err = s.ExportMessages(adminCtx, &rpc.ExportMessagesRequest{Chat: strPtr("export_a:export_b"), User: strPtr("export_a")})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_INVALID_ARGUMENT), resp.GetCode())
+	assert.Equal(t, exportTargetErr.Error(), resp.GetMsg())
+
+	resp, err = s.ExportMessages(adminCtx, &rpc.ExportMessagesRequest{User: strPtr("export_a"), Limit: maxExportMessages + 1})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_INVALID_LIMIT), resp.GetCode())
+
+	// Every chat of the user, ordered by chat then send time
+	resp, err = s.ExportMessages(adminCtx, &rpc.ExportMessagesRequest{User: strPtr("export_a"), Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), resp.GetCode())
+	assert.True(t, resp.GetHasMore())
+	if assert.Equal(t, 2, len(resp.GetMessages())) {
+		assert.Equal(t, "export_a:export_b", resp.GetMessages()[0].GetChat())
+		assert.Equal(t, "0", resp.GetMessages()[0].GetText())
+		assert.Equal(t, "2", resp.GetMessages()[1].GetText())
+	}
+
+	// A message sent during the export to a chat before the next page does not
+	// move it
+	sendResp, err := s.Send(ctx, &rpc.SendRequest{Message: &rpc.Message{Chat: "export_0:export_a", Text: "4", Sender: "export_0", SendTime: GetTimeNow().UnixMicro()}})
+	if err != nil || sendResp.GetCode() != 0 {
+		t.Fatalf("Error when sending a message during export test: %+v, code: %d\n", err, sendResp.GetCode())
+	}
+	resp, err = s.ExportMessages(adminCtx, &rpc.ExportMessagesRequest{User: strPtr("export_a"), Limit: 2, Cursor: strPtr(resp.GetNextCursor())})
+	assert.Nil(t, err)
+	assert.False(t, resp.GetHasMore())
+	assert.Empty(t, resp.GetNextCursor())
+	if assert.Equal(t, 1, len(resp.GetMessages())) {
+		assert.Equal(t, "export_a:export_c", resp.GetMessages()[0].GetChat())
+	}
+
+	for _, cursor := range []string{"2", "x:export_a:export_b", "1:export_a"} {
+		resp, err = s.ExportMessages(adminCtx, &rpc.ExportMessagesRequest{User: strPtr("export_a"), Cursor: strPtr(cursor)})
+		assert.Nil(t, err)
+		assert.Equal(t, int32(rpc.ErrorCode_INVALID_CURSOR), resp.GetCode(), cursor)
+	}
+
+	// Latest first, a message sent during the export to the chat being exported
+	// does not move the next page
+	reverse := true
+	resp, err = s.ExportMessages(userCtx, &rpc.ExportMessagesRequest{Chat: strPtr("export_b:export_a"), Limit: 1, Reverse: &reverse})
+	assert.Nil(t, err)
+	assert.True(t, resp.GetHasMore())
+	if assert.Equal(t, 1, len(resp.GetMessages())) {
+		assert.Equal(t, "2", resp.GetMessages()[0].GetText())
+	}
+	sendResp, err = s.Send(ctx, &rpc.SendRequest{Message: &rpc.Message{Chat: "export_a:export_b", Text: "5", Sender: "export_a", SendTime: GetTimeNow().UnixMicro()}})
+	if err != nil || sendResp.GetCode() != 0 {
+		t.Fatalf("Error when sending a message during export test: %+v, code: %d\n", err, sendResp.GetCode())
+	}
+	resp, err = s.ExportMessages(userCtx, &rpc.ExportMessagesRequest{Chat: strPtr("export_b:export_a"), Limit: 1, Reverse: &reverse, Cursor: strPtr(resp.GetNextCursor())})
+	assert.Nil(t, err)
+	assert.False(t, resp.GetHasMore())
+	if assert.Equal(t, 1, len(resp.GetMessages())) {
+		assert.Equal(t, "0", resp.GetMessages()[0].GetText())
+	}
+
+	// Only the first page of an export is recorded
+	var count int64
+	GetDatabase().Model(&AuditLog{}).Where("action = ? AND actor = ? AND target_user = ?", AuditActionExport, "export_admin", "export_a").Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestExportPage_SameSendTime(t *testing.T) {
+	ctx := context.Background()
+	var messages []*ChatMessage
+	for i, sentAt := range []uint64{1000, 2000, 2000, 2000, 3000} {
+		messages = append(messages, &ChatMessage{ChatID: "exportpage_a:exportpage_b", Sender: "exportpage_a", Receiver: "exportpage_b", Text: fmt.Sprint(i), SentAt: sentAt})
+	}
+	if err := GetDatabase().Create(messages).Error; err != nil {
+		t.Fatalf("Error when creating test messages for export page test: %+v\n", err)
+	}
+	texts := func(messages []*ChatMessage) []string {
+		var texts []string
+		for _, msg := range messages {
+			texts = append(texts, msg.Text)
+		}
+		return texts
+	}
+
+	// The page ends before the messages sent at the same time as the next
+	page, hasMore, err := exportPage(ctx, "exportpage_a:exportpage_b", "", false, nil, 2)
+	assert.Nil(t, err)
+	assert.True(t, hasMore)
+	assert.Equal(t, []string{"0"}, texts(page))
+
+	// More messages sent at the same time than the limit make a longer page
+	page, hasMore, err = exportPage(ctx, "exportpage_a:exportpage_b", "", false, positionOf(page[0]), 2)
+	assert.Nil(t, err)
+	assert.True(t, hasMore)
+	assert.ElementsMatch(t, []string{"1", "2", "3"}, texts(page))
+
+	page, hasMore, err = exportPage(ctx, "exportpage_a:exportpage_b", "", false, positionOf(page[0]), 2)
+	assert.Nil(t, err)
+	assert.False(t, hasMore)
+	assert.Equal(t, []string{"4"}, texts(page))
+
+	position, err := parseExportPosition(positionOf(page[0]).String())
+	assert.Nil(t, err)
+	assert.Equal(t, positionOf(page[0]), position)
+}
+
+func TestIMServiceImpl_ImportMessages(t *testing.T) {
+	InitAdmin(AdminConfig{Services: []string{"migration"}})
+	defer InitAdmin(AdminConfig{})
+	s := &IMServiceImpl{}
+	ctx := context.Background()
+	adminCtx := context.WithValue(ctx, CallerContextKey, &Caller{Service: "migration"})
+	message := func(text string, sendTime int64) *rpc.Message {
+		return &rpc.Message{Chat: "import_b:import_a", Text: text, Sender: "import_a", SendTime: sendTime}
+	}
+
+	resp, err := s.ImportMessages(ctx, &rpc.ImportMessagesRequest{Messages: []*rpc.Message{message("hi", 1000)}})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_PERMISSION_DENIED), resp.GetCode())
+
+	resp, err = s.ImportMessages(adminCtx, &rpc.ImportMessagesRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_INVALID_ARGUMENT), resp.GetCode())
+
+	resp, err = s.ImportMessages(adminCtx, &rpc.ImportMessagesRequest{Messages: []*rpc.Message{message("hi", 1000), message("no send time", 0)}})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_INVALID_ARGUMENT), resp.GetCode())
+	assert.Equal(t, "message 1: "+invalidSendTime.Error(), resp.GetMsg())
+
+	resp, err = s.ImportMessages(adminCtx, &rpc.ImportMessagesRequest{Messages: []*rpc.Message{
+		message("3", 3000), message("4", 4000), message("4", 4000),
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), resp.GetCode())
+	assert.Equal(t, int64(2), resp.GetImported())
+	assert.Equal(t, int64(1), resp.GetSkipped())
+
+	// Cache the position of the second message
+	pullResp, _ := s.Pull(ctx, &rpc.PullRequest{Chat: "import_a:import_b", Limit: 1})
+	pullResp, _ = s.Pull(ctx, &rpc.PullRequest{Chat: "import_a:import_b", Cursor: pullResp.GetNextCursor(), Limit: 1})
+	if assert.Equal(t, 1, len(pullResp.GetMessages())) {
+		assert.Equal(t, "4", pullResp.GetMessages()[0].GetText())
+	}
+
+	// Importing again skips the messages already stored, even with another sender
+	// at the same time
+	other := message("4", 4000)
+	other.Sender = "import_b"
+	resp, err = s.ImportMessages(adminCtx, &rpc.ImportMessagesRequest{Messages: []*rpc.Message{
+		message("1", 1000), message("3", 3000), message("4", 4000), other,
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), resp.GetImported())
+	assert.Equal(t, int64(2), resp.GetSkipped())
+
+	// The message imported before those stored moved their positions
+	pullResp, err = s.Pull(ctx, &rpc.PullRequest{Chat: "import_a:import_b", Cursor: 1, Limit: 10})
+	assert.Nil(t, err)
+	var texts []string
+	var sendTimes []int64
+	for _, msg := range pullResp.GetMessages() {
+		texts = append(texts, msg.GetText())
+		sendTimes = append(sendTimes, msg.GetSendTime())
+	}
+	assert.Equal(t, []string{"3", "4", "4"}, texts)
+	assert.Equal(t, []int64{3000, 4000, 4000}, sendTimes)
+
+	var count int64
+	GetDatabase().Model(&AuditLog{}).Where("action = ? AND actor_service = ? AND target_chat = ?", AuditActionImport, "migration", "import_a:import_b").Count(&count)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestWriteExport_ReadImport(t *testing.T) {
+	s := &IMServiceImpl{}
+	ctx := context.WithValue(context.Background(), CallerContextKey, cliCaller)
+	sendTimes := make(map[string]int64)
+	for i := 0; i < 3; i++ {
+		message := &rpc.Message{Chat: "roundtrip_a:roundtrip_b", Text: fmt.Sprintf("%d", i), Sender: "roundtrip_b", SendTime: GetTimeNow().UnixMicro()}
+		sendTimes[message.Text] = message.SendTime
+		if resp, err := s.Send(ctx, &rpc.SendRequest{Message: message}); err != nil || resp.GetCode() != 0 {
+			t.Fatalf("Error when creating test messages for round trip test: %+v, code: %d\n", err, resp.GetCode())
+		}
+	}
+
+	var buf bytes.Buffer
+	written, err := WriteExport(ctx, &buf, "", "roundtrip_a")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, written)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if assert.Equal(t, 3, len(lines)) {
+		record := new(exportRecord)
+		assert.Nil(t, json.Unmarshal([]byte(lines[0]), record))
+		assert.Equal(t, "roundtrip_a:roundtrip_b", record.ChatID)
+		assert.Equal(t, "roundtrip_b", record.Sender)
+		assert.Equal(t, "roundtrip_a", record.Receiver)
+		assert.Equal(t, uint64(sendTimes["0"]), record.SentAt)
+	}
+	export := buf.String()
+
+	imported, skipped, err := ReadImport(ctx, strings.NewReader(export), 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, imported)
+	assert.Equal(t, 3, skipped)
+
+	// Importing into a deployment without the chat restores it as exported
+	if err := GetDatabase().Where("chat_id = ?", "roundtrip_a:roundtrip_b").Delete(&ChatMessage{}).Error; err != nil {
+		t.Fatalf("Error when deleting messages for round trip test: %+v\n", err)
+	}
+	imported, skipped, err = ReadImport(ctx, strings.NewReader(export), 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, imported)
+	assert.Equal(t, 0, skipped)
+
+	resp, err := s.Pull(ctx, &rpc.PullRequest{Chat: "roundtrip_a:roundtrip_b", Limit: 10})
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(resp.GetMessages())) {
+		for _, msg := range resp.GetMessages() {
+			assert.Equal(t, sendTimes[msg.GetText()], msg.GetSendTime())
+		}
+	}
+
+	_, _, err = ReadImport(ctx, strings.NewReader(lines[0]+"\n{\"chat_id\": \"roundtrip_a:roundtrip_b\", \"sender\": \"roundtrip_c\", \"text\": \"hi\", \"sent_at\": 1}\n"), 2)
+	assert.EqualError(t, err, "record 2: "+invalidSender.Error())
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc"
+	"gorm.io/gorm"
+)
+
+var (
+	flagNotFoundErr        = errors.New("flag not found")
+	flagAlreadyReviewedErr = errors.New("flag already reviewed")
+)
+
+func (flag *FlaggedMessage) ToResponse() *rpc.ModerationFlag {
+	return &rpc.ModerationFlag{
+		Id:       int64(flag.ID),
+		Chat:     flag.ChatID,
+		Sender:   flag.Sender,
+		Text:     flag.Text,
+		SendTime: int64(flag.SentAt),
+		Filter:   flag.Filter,
+		Reason:   flag.Reason,
+		Reviewed: flag.Reviewed,
+	}
+}
+
+// queryFlags returns the flags from the cursor in the order they were raised,
+// fetching one more than the limit to tell whether there are more.
+func queryFlags(ctx context.Context, req *rpc.ListFlagsRequest) ([]*FlaggedMessage, error) {
+	query := GetDatabase().WithContext(ctx).Where("id >= ?", req.GetCursor())
+	if req.IsSetChat() {
+		query = query.Where("chat_id = ?", GetNormalisedChatID(req.GetChat()))
+	}
+	if !req.GetIncludeReviewed() {
+		query = query.Where("reviewed = ?", false)
+	}
+
+	var flags []*FlaggedMessage
+	err := query.Order("id ASC").Limit(int(req.GetLimit()) + 1).Find(&flags).Error
+	return flags, err
+}
+
+// reviewFlag marks a flag as reviewed, recording the review in the audit log in
+// the same transaction.
+func reviewFlag(ctx context.Context, id int64, note string) error {
+	db := GetDatabase().WithContext(ctx)
+	return db.Transaction(func(tx *gorm.DB) error {
+		var flag FlaggedMessage
+		if err := tx.First(&flag, id).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return flagNotFoundErr
+		} else if err != nil {
+			return err
+		}
+
+		// Only an unreviewed flag is updated, so that concurrent reviews of the
+		// same flag are recorded once
+		result := tx.Model(&FlaggedMessage{}).Where("id = ? AND reviewed = ?", id, false).Update("reviewed", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return flagAlreadyReviewedErr
+		}
+
+		details := fmt.Sprintf("flag %d raised by %s: %s", flag.ID, flag.Filter, flag.Reason)
+		if note != "" {
+			details += "; note: " + note
+		}
+		return RecordAudit(ctx, tx, &AuditLog{
+			Action:     AuditActionReviewFlag,
+			TargetChat: flag.ChatID,
+			TargetUser: flag.Sender,
+			Details:    details,
+		})
+	})
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIMServiceImpl_ListFlags_ReviewFlag(t *testing.T) {
+	InitAdmin(AdminConfig{Users: []string{"flags_admin"}})
+	defer InitAdmin(AdminConfig{})
+	moderationPipeline = NewModerationPipeline(NewBannedWordsFilter([]string{"darn"}, ModerationFlag))
+	defer func() { moderationPipeline = nil }()
+	s := &IMServiceImpl{}
+	userCtx := context.WithValue(context.Background(), CallerContextKey, &Caller{Service: "http-server", User: "flags_a"})
+	adminCtx := context.WithValue(context.Background(), CallerContextKey, &Caller{Service: "http-server", User: "flags_admin"})
+
+	for _, text := range []string{"darn", "fine", "darn it", "darn again"} {
+		resp, err := s.Send(userCtx, &rpc.SendRequest{Message: &rpc.Message{Chat: "flags_a:flags_b", Text: text, Sender: "flags_a"}})
+		assert.Nil(t, err)
+		assert.Equal(t, int32(0), resp.GetCode())
+	}
+
+	listResp, err := s.ListFlags(userCtx, &rpc.ListFlagsRequest{Chat: strPtr("flags_b:flags_a")})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(4), listResp.GetCode())
+
+	reviewResp, err := s.ReviewFlag(userCtx, &rpc.ReviewFlagRequest{Id: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(4), reviewResp.GetCode())
+
+	listResp, err = s.ListFlags(adminCtx, &rpc.ListFlagsRequest{Chat: strPtr("flags_b:flags_a"), Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), listResp.GetCode())
+	if !assert.Equal(t, 2, len(listResp.GetFlags())) {
+		t.FailNow()
+	}
+	assert.True(t, listResp.GetHasMore())
+	flag := listResp.GetFlags()[0]
+	assert.Equal(t, "flags_a:flags_b", flag.GetChat())
+	assert.Equal(t, "darn", flag.GetText())
+	assert.Equal(t, "banned_words", flag.GetFilter())
+	assert.False(t, flag.GetReviewed())
+
+	reviewResp, err = s.ReviewFlag(adminCtx, &rpc.ReviewFlagRequest{Id: flag.GetId(), Note: strPtr("harmless")})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), reviewResp.GetCode())
+
+	// A flag is reviewed once
+	reviewResp, err = s.ReviewFlag(adminCtx, &rpc.ReviewFlagRequest{Id: flag.GetId()})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_CONFLICT), reviewResp.GetCode())
+
+	reviewResp, err = s.ReviewFlag(adminCtx, &rpc.ReviewFlagRequest{Id: 1 << 40})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_NOT_FOUND), reviewResp.GetCode())
+
+	// Reviewed flags are only listed when asked for
+	listResp, err = s.ListFlags(adminCtx, &rpc.ListFlagsRequest{Chat: strPtr("flags_a:flags_b")})
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(listResp.GetFlags())) {
+		assert.Equal(t, "darn it", listResp.GetFlags()[0].GetText())
+		assert.Equal(t, "darn again", listResp.GetFlags()[1].GetText())
+	}
+	assert.False(t, listResp.GetHasMore())
+
+	includeReviewed := true
+	listResp, err = s.ListFlags(adminCtx, &rpc.ListFlagsRequest{Chat: strPtr("flags_a:flags_b"), IncludeReviewed: &includeReviewed})
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(listResp.GetFlags())) {
+		assert.True(t, listResp.GetFlags()[0].GetReviewed())
+	}
+
+	auditResp, err := s.QueryAuditLog(adminCtx, &rpc.QueryAuditLogRequest{Action: strPtr(AuditActionReviewFlag), TargetUser: strPtr("flags_a")})
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(auditResp.GetEntries())) {
+		assert.Equal(t, "flags_admin", auditResp.GetEntries()[0].GetActor())
+		assert.Equal(t, "flags_a:flags_b", auditResp.GetEntries()[0].GetTargetChat())
+		assert.Contains(t, auditResp.GetEntries()[0].GetDetails(), "harmless")
+	}
+
+	listResp, err = s.ListFlags(adminCtx, &rpc.ListFlagsRequest{Limit: -1})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_INVALID_LIMIT), listResp.GetCode())
+}
@@ -4,107 +4,574 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"time"
 
 	"github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc"
 	"github.com/jackc/pgx/v5/pgconn"
+	"golang.org/x/exp/slog"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // IMServiceImpl implements the last service interface defined in the IDL.
+//
+// Failures are reported through the ErrorCode in the response rather than by
+// returning an error, as Kitex discards the response when an error is returned
+// and callers would not receive the code.
 type IMServiceImpl struct{}
 
 var (
-	invalidCursorErr = errors.New("invalid cursor")
-	invalidLimitErr  = errors.New("invalid limit")
+	invalidCursorErr    = errors.New("invalid cursor")
+	invalidLimitErr     = errors.New("invalid limit")
+	permissionDeniedErr = errors.New("permission denied")
+	blockedErr          = errors.New("sender is blocked by receiver")
+	keyExistsErr        = errors.New("a different key with this key id already exists")
+	invalidBatchSizeErr = fmt.Errorf("a batch must have between 1 and %d messages", maxBatchSendMessages)
+	invalidChatCountErr = fmt.Errorf("between 1 and %d chats must be pulled", maxMultiPullChats)
+)
+
+const (
+	maxBatchSendMessages = 100
+	maxMultiPullChats    = 50
 )
 
 func (s *IMServiceImpl) Send(ctx context.Context, req *rpc.SendRequest) (*rpc.SendResponse, error) {
 	resp := rpc.NewSendResponse()
 
-	userMessage := req.GetMessage()
-	if err := ValidateMessage(userMessage); err != nil {
-		resp.Code = 1
+	chatMessage, flags, status := prepareMessage(ctx, req.GetMessage())
+	if status != nil {
+		resp.Code, resp.Msg, resp.RetryAfter = status.Code, status.Msg, status.RetryAfter
+		return resp, nil
+	}
+
+	if err := storeMessages(ctx, chatMessage.ChatID, []*ChatMessage{chatMessage}, flags); err != nil {
+		resp.Code = int32(rpc.ErrorCode_INTERNAL)
+		resp.Msg = "something went wrong..."
+		slog.ErrorCtx(ctx, "error when creating message", "error", err)
+		return resp, nil
+	}
+
+	resp.Code, resp.Msg = 0, "success"
+	return resp, nil
+}
+
+func (s *IMServiceImpl) Pull(ctx context.Context, req *rpc.PullRequest) (*rpc.PullResponse, error) {
+	resp := rpc.NewPullResponse()
+
+	if err := ValidateChatID(req.GetChat()); err != nil {
+		resp.Code = int32(rpc.ErrorCode_INVALID_ARGUMENT)
 		resp.Msg = err.Error()
-		return resp, err
+		return resp, nil
+	} else {
+		req.SetChat(GetNormalisedChatID(req.GetChat()))
 	}
 
-	sender, receiver := GetSenderReceiver(userMessage)
-	chatMessage := &ChatMessage{
-		ChatID:   GetNormalisedChatIDFromMessage(userMessage),
-		Sender:   sender,
-		Receiver: receiver,
-		Text:     userMessage.GetText(),
-		SentAt:   uint64(userMessage.SendTime),
+	if user := GetCallerUser(ctx); user != "" && !IsChatMember(req.GetChat(), user) {
+		resp.Code = int32(rpc.ErrorCode_PERMISSION_DENIED)
+		resp.Msg = permissionDeniedErr.Error()
+		return resp, nil
 	}
 
-	db := GetDatabase()
-	if err := db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Create(chatMessage).Error; err != nil {
-			return err
+	if req.GetLimit() < 0 {
+		resp.Code = int32(rpc.ErrorCode_INVALID_LIMIT)
+		resp.Msg = invalidLimitErr.Error()
+		return resp, nil
+	} else if req.GetLimit() == 0 {
+		req.SetLimit(10)
+	}
+
+	if req.GetCursor() < 0 {
+		resp.Code = int32(rpc.ErrorCode_INVALID_CURSOR)
+		resp.Msg = invalidCursorErr.Error()
+		return resp, nil
+	}
+
+	messages, hasMore, nextCursor, err := pullMessages(ctx, req)
+	if err != nil {
+		resp.Code = int32(rpc.ErrorCode_INTERNAL)
+		resp.Msg = "something went wrong..."
+		slog.ErrorCtx(ctx, "error when retrieving messages", "error", err)
+		return resp, nil
+	}
+
+	resp.SetHasMore(&hasMore)
+	resp.SetNextCursor(nextCursor)
+	resp.SetMessages(messages)
+	resp.Code, resp.Msg = 0, "success"
+	return resp, nil
+}
+
+func (s *IMServiceImpl) Block(ctx context.Context, req *rpc.BlockRequest) (*rpc.BlockResponse, error) {
+	resp := rpc.NewBlockResponse()
+
+	if err := ValidateBlock(req.GetUser(), req.GetBlocked()); err != nil {
+		resp.Code = int32(rpc.ErrorCode_INVALID_ARGUMENT)
+		resp.Msg = err.Error()
+		return resp, nil
+	}
+
+	if user := GetCallerUser(ctx); user != "" && user != req.GetUser() {
+		resp.Code = int32(rpc.ErrorCode_PERMISSION_DENIED)
+		resp.Msg = permissionDeniedErr.Error()
+		return resp, nil
+	}
+
+	userBlock := &UserBlock{
+		Blocker:   req.GetUser(),
+		Blocked:   req.GetBlocked(),
+		CreatedAt: uint64(GetTimeNow().UnixMicro()),
+	}
+	if err := GetDatabase().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(userBlock)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
 		}
 
-		if err := tx.Where("chat_id = ? AND reverse = true", chatMessage.ChatID).Delete(&ChatCursorCache{}).Error; err != nil {
-			return err
+		return RecordAudit(ctx, tx, &AuditLog{
+			Action:     AuditActionBlock,
+			Actor:      req.GetUser(),
+			TargetUser: req.GetBlocked(),
+		})
+	}); err != nil {
+		resp.Code = int32(rpc.ErrorCode_INTERNAL)
+		resp.Msg = "something went wrong..."
+		slog.ErrorCtx(ctx, "error when blocking user", "error", err)
+		return resp, nil
+	}
+
+	resp.Code, resp.Msg = 0, "success"
+	return resp, nil
+}
+
+func (s *IMServiceImpl) Unblock(ctx context.Context, req *rpc.UnblockRequest) (*rpc.UnblockResponse, error) {
+	resp := rpc.NewUnblockResponse()
+
+	if err := ValidateBlock(req.GetUser(), req.GetBlocked()); err != nil {
+		resp.Code = int32(rpc.ErrorCode_INVALID_ARGUMENT)
+		resp.Msg = err.Error()
+		return resp, nil
+	}
+
+	if user := GetCallerUser(ctx); user != "" && user != req.GetUser() {
+		resp.Code = int32(rpc.ErrorCode_PERMISSION_DENIED)
+		resp.Msg = permissionDeniedErr.Error()
+		return resp, nil
+	}
+
+	if err := GetDatabase().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("blocker = ? AND blocked = ?", req.GetUser(), req.GetBlocked()).Delete(&UserBlock{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
 		}
 
-		return nil
+		return RecordAudit(ctx, tx, &AuditLog{
+			Action:     AuditActionUnblock,
+			Actor:      req.GetUser(),
+			TargetUser: req.GetBlocked(),
+		})
 	}); err != nil {
-		resp.Code = -1
+		resp.Code = int32(rpc.ErrorCode_INTERNAL)
 		resp.Msg = "something went wrong..."
-		log.Printf("Error when creating message: %+v\n", err)
-		return resp, err
+		slog.ErrorCtx(ctx, "error when unblocking user", "error", err)
+		return resp, nil
 	}
 
 	resp.Code, resp.Msg = 0, "success"
 	return resp, nil
 }
 
-func (s *IMServiceImpl) Pull(ctx context.Context, req *rpc.PullRequest) (*rpc.PullResponse, error) {
-	resp := rpc.NewPullResponse()
+func (s *IMServiceImpl) ListBlocked(ctx context.Context, req *rpc.ListBlockedRequest) (*rpc.ListBlockedResponse, error) {
+	resp := rpc.NewListBlockedResponse()
 
-	if err := ValidateChatID(req.GetChat()); err != nil {
-		resp.Code = 1
+	if err := ValidateUser(req.GetUser()); err != nil {
+		resp.Code = int32(rpc.ErrorCode_INVALID_ARGUMENT)
 		resp.Msg = err.Error()
-		return resp, err
-	} else {
-		req.SetChat(GetNormalisedChatID(req.GetChat()))
+		return resp, nil
+	}
+
+	if user := GetCallerUser(ctx); user != "" && user != req.GetUser() {
+		resp.Code = int32(rpc.ErrorCode_PERMISSION_DENIED)
+		resp.Msg = permissionDeniedErr.Error()
+		return resp, nil
+	}
+
+	blocked := make([]string, 0)
+	if err := GetDatabase().WithContext(ctx).Model(&UserBlock{}).Where("blocker = ?", req.GetUser()).Order("blocked ASC").Pluck("blocked", &blocked).Error; err != nil {
+		resp.Code = int32(rpc.ErrorCode_INTERNAL)
+		resp.Msg = "something went wrong..."
+		slog.ErrorCtx(ctx, "error when listing blocked users", "error", err)
+		return resp, nil
+	}
+
+	resp.SetBlocked(blocked)
+	resp.Code, resp.Msg = 0, "success"
+	return resp, nil
+}
+
+func (s *IMServiceImpl) QueryAuditLog(ctx context.Context, req *rpc.QueryAuditLogRequest) (*rpc.QueryAuditLogResponse, error) {
+	resp := rpc.NewQueryAuditLogResponse()
+
+	if !IsAdmin(ctx) {
+		resp.Code = int32(rpc.ErrorCode_PERMISSION_DENIED)
+		resp.Msg = permissionDeniedErr.Error()
+		return resp, nil
 	}
 
 	if req.GetLimit() < 0 {
-		resp.Code = 2
+		resp.Code = int32(rpc.ErrorCode_INVALID_LIMIT)
 		resp.Msg = invalidLimitErr.Error()
-		return resp, invalidLimitErr
+		return resp, nil
 	} else if req.GetLimit() == 0 {
-		req.SetLimit(10)
+		req.SetLimit(50)
 	}
 
 	if req.GetCursor() < 0 {
-		resp.Code = 3
+		resp.Code = int32(rpc.ErrorCode_INVALID_CURSOR)
 		resp.Msg = invalidCursorErr.Error()
-		return resp, invalidCursorErr
+		return resp, nil
 	}
 
-	messages, err := getMessages(req)
+	entries, err := queryAuditLog(ctx, req)
 	if err != nil {
-		resp.Code = -1
+		resp.Code = int32(rpc.ErrorCode_INTERNAL)
+		resp.Msg = "something went wrong..."
+		slog.ErrorCtx(ctx, "error when querying audit log", "error", err)
+		return resp, nil
+	}
+
+	limit := int(req.GetLimit())
+	hasMore := false
+	respEntries := make([]*rpc.AuditEntry, Min(limit, len(entries)))
+	for i, entry := range entries {
+		if i == limit {
+			hasMore = true
+			nextCursor := int64(entry.ID)
+			resp.SetNextCursor(&nextCursor)
+			break
+		}
+		respEntries[i] = entry.ToResponse()
+	}
+
+	resp.SetHasMore(&hasMore)
+	resp.SetEntries(respEntries)
+	resp.Code, resp.Msg = 0, "success"
+	return resp, nil
+}
+
+func (s *IMServiceImpl) PublishKey(ctx context.Context, req *rpc.PublishKeyRequest) (*rpc.PublishKeyResponse, error) {
+	resp := rpc.NewPublishKeyResponse()
+
+	key := req.GetKey()
+	if err := ValidateIdentityKey(key); err != nil {
+		resp.Code = int32(rpc.ErrorCode_INVALID_ARGUMENT)
+		resp.Msg = err.Error()
+		return resp, nil
+	}
+
+	if user := GetCallerUser(ctx); user != "" && user != key.GetUser() {
+		resp.Code = int32(rpc.ErrorCode_PERMISSION_DENIED)
+		resp.Msg = permissionDeniedErr.Error()
+		return resp, nil
+	}
+
+	identityKey := &UserIdentityKey{
+		UserID:    key.GetUser(),
+		KeyID:     key.GetKeyId(),
+		Algorithm: key.GetAlgorithm(),
+		PublicKey: key.GetPublicKey(),
+		CreatedAt: uint64(GetTimeNow().UnixMicro()),
+	}
+	db := GetDatabase().WithContext(ctx)
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(identityKey)
+		if result.Error != nil || result.RowsAffected > 0 {
+			return result.Error
+		}
+
+		// Keys are immutable once published, only allow idempotent republishing
+		existing := new(UserIdentityKey)
+		if err := tx.Where("user_id = ? AND key_id = ?", identityKey.UserID, identityKey.KeyID).First(existing).Error; err != nil {
+			return err
+		}
+		if existing.Algorithm != identityKey.Algorithm || existing.PublicKey != identityKey.PublicKey {
+			return keyExistsErr
+		}
+		return nil
+	}); errors.Is(err, keyExistsErr) {
+		resp.Code = int32(rpc.ErrorCode_CONFLICT)
+		resp.Msg = err.Error()
+		return resp, nil
+	} else if err != nil {
+		resp.Code = int32(rpc.ErrorCode_INTERNAL)
+		resp.Msg = "something went wrong..."
+		slog.ErrorCtx(ctx, "error when publishing identity key", "error", err)
+		return resp, nil
+	}
+
+	resp.Code, resp.Msg = 0, "success"
+	return resp, nil
+}
+
+func (s *IMServiceImpl) GetKeys(ctx context.Context, req *rpc.GetKeysRequest) (*rpc.GetKeysResponse, error) {
+	resp := rpc.NewGetKeysResponse()
+
+	if err := ValidateUser(req.GetUser()); err != nil {
+		resp.Code = int32(rpc.ErrorCode_INVALID_ARGUMENT)
+		resp.Msg = err.Error()
+		return resp, nil
+	}
+
+	var identityKeys []*UserIdentityKey
+	if err := GetDatabase().WithContext(ctx).Where("user_id = ?", req.GetUser()).Order("created_at ASC").Find(&identityKeys).Error; err != nil {
+		resp.Code = int32(rpc.ErrorCode_INTERNAL)
+		resp.Msg = "something went wrong..."
+		slog.ErrorCtx(ctx, "error when retrieving identity keys", "error", err)
+		return resp, nil
+	}
+
+	keys := make([]*rpc.IdentityKey, len(identityKeys))
+	for i, identityKey := range identityKeys {
+		keys[i] = identityKey.ToResponse()
+	}
+
+	resp.SetKeys(keys)
+	resp.Code, resp.Msg = 0, "success"
+	return resp, nil
+}
+
+func (s *IMServiceImpl) BatchSend(ctx context.Context, req *rpc.BatchSendRequest) (*rpc.BatchSendResponse, error) {
+	resp := rpc.NewBatchSendResponse()
+
+	if len(req.GetMessages()) == 0 || len(req.GetMessages()) > maxBatchSendMessages {
+		resp.Code = int32(rpc.ErrorCode_INVALID_ARGUMENT)
+		resp.Msg = invalidBatchSizeErr.Error()
+		return resp, nil
+	}
+
+	// Messages are stored in a transaction per chat, in the order they were sent
+	statuses := make([]*rpc.SendStatus, len(req.GetMessages()))
+	var chats []string
+	batches := make(map[string]*messageBatch)
+	for i, message := range req.GetMessages() {
+		chatMessage, flags, status := prepareMessage(ctx, message)
+		if status != nil {
+			statuses[i] = status
+			continue
+		}
+
+		batch, ok := batches[chatMessage.ChatID]
+		if !ok {
+			batch = new(messageBatch)
+			batches[chatMessage.ChatID] = batch
+			chats = append(chats, chatMessage.ChatID)
+		}
+		batch.indices = append(batch.indices, i)
+		batch.messages = append(batch.messages, chatMessage)
+		batch.flags = append(batch.flags, flags...)
+	}
+
+	for _, chatId := range chats {
+		batch := batches[chatId]
+		status := &rpc.SendStatus{Code: 0, Msg: "success"}
+		if err := storeMessages(ctx, chatId, batch.messages, batch.flags); err != nil {
+			status = &rpc.SendStatus{Code: int32(rpc.ErrorCode_INTERNAL), Msg: "something went wrong..."}
+			slog.ErrorCtx(ctx, "error when creating messages", "chat", chatId, "error", err)
+		}
+		for _, i := range batch.indices {
+			statuses[i] = status
+		}
+	}
+
+	resp.SetStatuses(statuses)
+	resp.Code, resp.Msg = 0, "success"
+	return resp, nil
+}
+
+func (s *IMServiceImpl) MultiPull(ctx context.Context, req *rpc.MultiPullRequest) (*rpc.MultiPullResponse, error) {
+	resp := rpc.NewMultiPullResponse()
+
+	if len(req.GetChats()) == 0 || len(req.GetChats()) > maxMultiPullChats {
+		resp.Code = int32(rpc.ErrorCode_INVALID_ARGUMENT)
+		resp.Msg = invalidChatCountErr.Error()
+		return resp, nil
+	}
+
+	if req.GetLimit() < 0 {
+		resp.Code = int32(rpc.ErrorCode_INVALID_LIMIT)
+		resp.Msg = invalidLimitErr.Error()
+		return resp, nil
+	} else if req.GetLimit() == 0 {
+		req.SetLimit(10)
+	}
+
+	user := GetCallerUser(ctx)
+	pages := make([]*rpc.ChatPage, len(req.GetChats()))
+	for i, chatId := range req.GetChats() {
+		page := &rpc.ChatPage{Chat: chatId}
+		pages[i] = page
+
+		if err := ValidateChatID(chatId); err != nil {
+			page.Code, page.Msg = int32(rpc.ErrorCode_INVALID_ARGUMENT), err.Error()
+			continue
+		}
+		page.Chat = GetNormalisedChatID(chatId)
+
+		if user != "" && !IsChatMember(page.Chat, user) {
+			page.Code, page.Msg = int32(rpc.ErrorCode_PERMISSION_DENIED), permissionDeniedErr.Error()
+			continue
+		}
+
+		reverse := req.GetReverse()
+		messages, hasMore, nextCursor, err := pullMessages(ctx, &rpc.PullRequest{
+			Chat:    page.Chat,
+			Limit:   req.GetLimit(),
+			Reverse: &reverse,
+		})
+		if err != nil {
+			page.Code, page.Msg = int32(rpc.ErrorCode_INTERNAL), "something went wrong..."
+			slog.ErrorCtx(ctx, "error when retrieving messages", "chat", page.Chat, "error", err)
+			continue
+		}
+
+		page.SetMessages(messages)
+		page.SetHasMore(&hasMore)
+		page.SetNextCursor(nextCursor)
+		page.Code, page.Msg = 0, "success"
+	}
+
+	resp.SetChats(pages)
+	resp.Code, resp.Msg = 0, "success"
+	return resp, nil
+}
+
+func (s *IMServiceImpl) ListChats(ctx context.Context, req *rpc.ListChatsRequest) (*rpc.ListChatsResponse, error) {
+	resp := rpc.NewListChatsResponse()
+
+	if err := ValidateUser(req.GetUser()); err != nil {
+		resp.Code = int32(rpc.ErrorCode_INVALID_ARGUMENT)
 		resp.Msg = err.Error()
-		return resp, err
+		return resp, nil
+	}
+
+	if user := GetCallerUser(ctx); user != "" && user != req.GetUser() {
+		resp.Code = int32(rpc.ErrorCode_PERMISSION_DENIED)
+		resp.Msg = permissionDeniedErr.Error()
+		return resp, nil
 	}
 
+	if req.GetLimit() < 0 {
+		resp.Code = int32(rpc.ErrorCode_INVALID_LIMIT)
+		resp.Msg = invalidLimitErr.Error()
+		return resp, nil
+	} else if req.GetLimit() == 0 {
+		req.SetLimit(50)
+	}
+
+	if req.GetCursor() < 0 {
+		resp.Code = int32(rpc.ErrorCode_INVALID_CURSOR)
+		resp.Msg = invalidCursorErr.Error()
+		return resp, nil
+	}
+
+	var summaries []*chatSummary
 	limit := int(req.GetLimit())
+	start := time.Now()
+	err := GetDatabase().WithContext(ctx).Model(&ChatMessage{}).
+		Select("chat_id, MAX(sent_at) AS last_sent_at, COUNT(*) AS message_count").
+		Where("sender = ? OR receiver = ?", req.GetUser(), req.GetUser()).
+		Group("chat_id").
+		Order("last_sent_at DESC, chat_id ASC").
+		Offset(int(req.GetCursor())).Limit(limit + 1).
+		Scan(&summaries).Error
+	observeQuery("chats_by_user", start)
+	if err != nil {
+		resp.Code = int32(rpc.ErrorCode_INTERNAL)
+		resp.Msg = "something went wrong..."
+		slog.ErrorCtx(ctx, "error when listing chats", "error", err)
+		return resp, nil
+	}
+
 	hasMore := false
-	respMessages := make([]*rpc.Message, Min(limit, len(messages)))
-	for i, msg := range messages {
+	chats := make([]*rpc.ChatSummary, Min(limit, len(summaries)))
+	for i, summary := range summaries {
 		if i == limit {
 			hasMore = true
 			nextCursor := req.GetCursor() + int64(limit)
 			resp.SetNextCursor(&nextCursor)
-			createChatCursorCache(msg, req.GetReverse(), nextCursor)
 			break
 		}
+		chats[i] = &rpc.ChatSummary{
+			Chat:         summary.ChatID,
+			LastSendTime: int64(summary.LastSentAt),
+			MessageCount: summary.MessageCount,
+		}
+	}
+
+	resp.SetHasMore(&hasMore)
+	resp.SetChats(chats)
+	resp.Code, resp.Msg = 0, "success"
+	return resp, nil
+}
+
+func (s *IMServiceImpl) ExportMessages(ctx context.Context, req *rpc.ExportMessagesRequest) (*rpc.ExportMessagesResponse, error) {
+	resp := rpc.NewExportMessagesResponse()
+
+	if err := ValidateExportTarget(req.GetChat(), req.GetUser()); err != nil {
+		resp.Code = int32(rpc.ErrorCode_INVALID_ARGUMENT)
+		resp.Msg = err.Error()
+		return resp, nil
+	}
+
+	if !mayExport(ctx, req.GetChat(), req.GetUser()) {
+		resp.Code = int32(rpc.ErrorCode_PERMISSION_DENIED)
+		resp.Msg = permissionDeniedErr.Error()
+		return resp, nil
+	}
+
+	if req.GetLimit() < 0 || req.GetLimit() > maxExportMessages {
+		resp.Code = int32(rpc.ErrorCode_INVALID_LIMIT)
+		resp.Msg = invalidLimitErr.Error()
+		return resp, nil
+	} else if req.GetLimit() == 0 {
+		req.SetLimit(500)
+	}
+
+	var after *exportPosition
+	if req.GetCursor() != "" {
+		var err error
+		if after, err = parseExportPosition(req.GetCursor()); err != nil {
+			resp.Code = int32(rpc.ErrorCode_INVALID_CURSOR)
+			resp.Msg = err.Error()
+			return resp, nil
+		}
+	}
+
+	// An export is recorded once, when its first page is requested
+	if after == nil {
+		if err := recordExport(ctx, req.GetChat(), req.GetUser()); err != nil {
+			resp.Code = int32(rpc.ErrorCode_INTERNAL)
+			resp.Msg = "something went wrong..."
+			slog.ErrorCtx(ctx, "error when recording export", "error", err)
+			return resp, nil
+		}
+	}
+
+	messages, hasMore, err := exportPage(ctx, req.GetChat(), req.GetUser(), req.GetReverse(), after, int(req.GetLimit()))
+	if err != nil {
+		resp.Code = int32(rpc.ErrorCode_INTERNAL)
+		resp.Msg = "something went wrong..."
+		slog.ErrorCtx(ctx, "error when exporting messages", "error", err)
+		return resp, nil
+	}
+
+	respMessages := make([]*rpc.Message, len(messages))
+	for i, msg := range messages {
 		respMessages[i] = msg.ToResponse()
 	}
+	if hasMore {
+		nextCursor := positionOf(messages[len(messages)-1]).String()
+		resp.SetNextCursor(&nextCursor)
+	}
 
 	resp.SetHasMore(&hasMore)
 	resp.SetMessages(respMessages)
@@ -112,24 +579,338 @@ func (s *IMServiceImpl) Pull(ctx context.Context, req *rpc.PullRequest) (*rpc.Pu
 	return resp, nil
 }
 
-func createChatCursorCache(msg *ChatMessage, reverse bool, cursor int64) {
+func (s *IMServiceImpl) ImportMessages(ctx context.Context, req *rpc.ImportMessagesRequest) (*rpc.ImportMessagesResponse, error) {
+	resp := rpc.NewImportMessagesResponse()
+
+	if !IsAdmin(ctx) {
+		resp.Code = int32(rpc.ErrorCode_PERMISSION_DENIED)
+		resp.Msg = permissionDeniedErr.Error()
+		return resp, nil
+	}
+
+	if len(req.GetMessages()) == 0 || len(req.GetMessages()) > maxImportMessages {
+		resp.Code = int32(rpc.ErrorCode_INVALID_ARGUMENT)
+		resp.Msg = invalidImportSizeErr.Error()
+		return resp, nil
+	}
+
+	messages := make([]*ChatMessage, len(req.GetMessages()))
+	for i, message := range req.GetMessages() {
+		msg, err := newImportedMessage(message)
+		if err != nil {
+			resp.Code = int32(rpc.ErrorCode_INVALID_ARGUMENT)
+			resp.Msg = fmt.Sprintf("message %d: %s", i, err.Error())
+			return resp, nil
+		}
+		messages[i] = msg
+	}
+
+	imported, skipped, err := importMessages(ctx, messages)
+	if err != nil {
+		resp.Code = int32(rpc.ErrorCode_INTERNAL)
+		resp.Msg = "something went wrong..."
+		slog.ErrorCtx(ctx, "error when importing messages", "error", err)
+		return resp, nil
+	}
+
+	importedCount, skippedCount := int64(imported), int64(skipped)
+	resp.SetImported(&importedCount)
+	resp.SetSkipped(&skippedCount)
+	resp.Code, resp.Msg = 0, "success"
+	return resp, nil
+}
+
+func (s *IMServiceImpl) EraseUser(ctx context.Context, req *rpc.EraseUserRequest) (*rpc.EraseUserResponse, error) {
+	resp := rpc.NewEraseUserResponse()
+
+	if !IsAdmin(ctx) {
+		resp.Code = int32(rpc.ErrorCode_PERMISSION_DENIED)
+		resp.Msg = permissionDeniedErr.Error()
+		return resp, nil
+	}
+
+	if err := ValidateUser(req.GetUser()); err != nil {
+		resp.Code = int32(rpc.ErrorCode_INVALID_ARGUMENT)
+		resp.Msg = err.Error()
+		return resp, nil
+	}
+
+	if req.GetMode() != rpc.ErasureMode_REDACT && req.GetMode() != rpc.ErasureMode_DELETE {
+		resp.Code = int32(rpc.ErrorCode_INVALID_ARGUMENT)
+		resp.Msg = invalidErasureModeErr.Error()
+		return resp, nil
+	}
+
+	report, err := eraseUser(ctx, req.GetUser(), req.GetMode())
+	if err != nil {
+		resp.Code = int32(rpc.ErrorCode_INTERNAL)
+		resp.Msg = "something went wrong..."
+		slog.ErrorCtx(ctx, "error when erasing user", "error", err)
+		return resp, nil
+	}
+
+	resp.SetReport(report)
+	resp.Code, resp.Msg = 0, "success"
+	return resp, nil
+}
+
+func (s *IMServiceImpl) ListFlags(ctx context.Context, req *rpc.ListFlagsRequest) (*rpc.ListFlagsResponse, error) {
+	resp := rpc.NewListFlagsResponse()
+
+	if !IsAdmin(ctx) {
+		resp.Code = int32(rpc.ErrorCode_PERMISSION_DENIED)
+		resp.Msg = permissionDeniedErr.Error()
+		return resp, nil
+	}
+
+	if req.GetLimit() < 0 {
+		resp.Code = int32(rpc.ErrorCode_INVALID_LIMIT)
+		resp.Msg = invalidLimitErr.Error()
+		return resp, nil
+	} else if req.GetLimit() == 0 {
+		req.SetLimit(50)
+	}
+
+	if req.GetCursor() < 0 {
+		resp.Code = int32(rpc.ErrorCode_INVALID_CURSOR)
+		resp.Msg = invalidCursorErr.Error()
+		return resp, nil
+	}
+
+	flags, err := queryFlags(ctx, req)
+	if err != nil {
+		resp.Code = int32(rpc.ErrorCode_INTERNAL)
+		resp.Msg = "something went wrong..."
+		slog.ErrorCtx(ctx, "error when listing flags", "error", err)
+		return resp, nil
+	}
+
+	limit := int(req.GetLimit())
+	hasMore := false
+	respFlags := make([]*rpc.ModerationFlag, Min(limit, len(flags)))
+	for i, flag := range flags {
+		if i == limit {
+			hasMore = true
+			nextCursor := int64(flag.ID)
+			resp.SetNextCursor(&nextCursor)
+			break
+		}
+		respFlags[i] = flag.ToResponse()
+	}
+
+	resp.SetHasMore(&hasMore)
+	resp.SetFlags(respFlags)
+	resp.Code, resp.Msg = 0, "success"
+	return resp, nil
+}
+
+func (s *IMServiceImpl) ReviewFlag(ctx context.Context, req *rpc.ReviewFlagRequest) (*rpc.ReviewFlagResponse, error) {
+	resp := rpc.NewReviewFlagResponse()
+
+	if !IsAdmin(ctx) {
+		resp.Code = int32(rpc.ErrorCode_PERMISSION_DENIED)
+		resp.Msg = permissionDeniedErr.Error()
+		return resp, nil
+	}
+
+	err := reviewFlag(ctx, req.GetId(), req.GetNote())
+	switch {
+	case errors.Is(err, flagNotFoundErr):
+		resp.Code = int32(rpc.ErrorCode_NOT_FOUND)
+		resp.Msg = err.Error()
+		return resp, nil
+	case errors.Is(err, flagAlreadyReviewedErr):
+		resp.Code = int32(rpc.ErrorCode_CONFLICT)
+		resp.Msg = err.Error()
+		return resp, nil
+	case err != nil:
+		resp.Code = int32(rpc.ErrorCode_INTERNAL)
+		resp.Msg = "something went wrong..."
+		slog.ErrorCtx(ctx, "error when reviewing flag", "error", err)
+		return resp, nil
+	}
+
+	resp.Code, resp.Msg = 0, "success"
+	return resp, nil
+}
+
+// chatSummary is a row of the chats listed by ListChats.
+type chatSummary struct {
+	ChatID       string
+	LastSentAt   uint64
+	MessageCount int64
+}
+
+// messageBatch holds the messages of a BatchSend to be stored in a chat, along
+// with their positions in the request.
+type messageBatch struct {
+	indices  []int
+	messages []*ChatMessage
+	flags    []*FlaggedMessage
+}
+
+// prepareMessage validates a message and applies the checks every message
+// sent goes through, returning the message to be stored along with the flags
+// raised by moderation. If the message is not to be stored, the status to
+// respond with is returned instead.
+func prepareMessage(ctx context.Context, userMessage *rpc.Message) (*ChatMessage, []*FlaggedMessage, *rpc.SendStatus) {
+	if err := ValidateMessage(userMessage); err != nil {
+		return nil, nil, &rpc.SendStatus{Code: int32(rpc.ErrorCode_INVALID_ARGUMENT), Msg: err.Error()}
+	}
+
+	if user := GetCallerUser(ctx); user != "" && user != userMessage.GetSender() {
+		return nil, nil, &rpc.SendStatus{Code: int32(rpc.ErrorCode_PERMISSION_DENIED), Msg: permissionDeniedErr.Error()}
+	}
+
+	sender, receiver := GetSenderReceiver(userMessage)
+	chatId := GetNormalisedChatIDFromMessage(userMessage)
+	if blocked, err := isBlocked(ctx, receiver, sender); err != nil {
+		slog.ErrorCtx(ctx, "error when checking blocked users", "error", err)
+		return nil, nil, &rpc.SendStatus{Code: int32(rpc.ErrorCode_INTERNAL), Msg: "something went wrong..."}
+	} else if blocked {
+		return nil, nil, &rpc.SendStatus{Code: int32(rpc.ErrorCode_BLOCKED), Msg: blockedErr.Error()}
+	}
+
+	if limiter := GetRateLimiter(); limiter != nil {
+		allowed, retryAfter, err := limiter.Allow(ctx, sender, chatId)
+		if err != nil {
+			// Fail open, a broken rate limiter should not stop messages from being sent
+			slog.ErrorCtx(ctx, "error when checking rate limit", "error", err)
+		} else if !allowed {
+			retryAfterMillis := retryAfter.Milliseconds()
+			return nil, nil, &rpc.SendStatus{Code: int32(rpc.ErrorCode_RATE_LIMITED), Msg: rateLimitedErr.Error(), RetryAfter: &retryAfterMillis}
+		}
+	}
+
+	// Flags record the text as sent, before any filter masks it
+	originalText := userMessage.GetText()
+	var moderation *ModerationResult
+	// Encrypted envelopes only go through the filters which do not inspect them
+	if pipeline := GetModerationPipeline(); pipeline != nil {
+		moderation = pipeline.Moderate(ctx, userMessage)
+		if moderation.Rejected {
+			return nil, nil, &rpc.SendStatus{
+				Code: int32(rpc.ErrorCode_MESSAGE_REJECTED),
+				Msg:  fmt.Sprintf("%s: %s", rejectedMessageErr.Error(), moderation.Reason),
+			}
+		}
+	}
+
+	chatMessage := &ChatMessage{
+		ChatID:   chatId,
+		Sender:   sender,
+		Receiver: receiver,
+		Text:     userMessage.GetText(),
+		SentAt:   uint64(userMessage.SendTime),
+	}
+	if userMessage.GetEncrypted() {
+		chatMessage.Encrypted = true
+		chatMessage.SenderKeyID = userMessage.GetSenderKeyId()
+		chatMessage.RecipientKeyID = userMessage.GetRecipientKeyId()
+	}
+
+	var flags []*FlaggedMessage
+	if moderation != nil {
+		for _, flag := range moderation.Flags {
+			flag.ChatID = chatMessage.ChatID
+			flag.Sender = chatMessage.Sender
+			flag.Text = originalText
+			flag.SentAt = chatMessage.SentAt
+			flags = append(flags, flag)
+		}
+	}
+	return chatMessage, flags, nil
+}
+
+// storeMessages stores messages sent to a chat, and the flags moderation raised
+// for them, in a single transaction, along with their message.created events if
+// the outbox is enabled.
+func storeMessages(ctx context.Context, chatId string, messages []*ChatMessage, flags []*FlaggedMessage) error {
+	db := GetDatabase().WithContext(ctx)
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(messages).Error; err != nil {
+			return err
+		}
+
+		if outboxEnabled {
+			events, err := newMessageCreatedEvents(messages)
+			if err != nil {
+				return err
+			}
+			if err := tx.Create(events).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("chat_id = ? AND reverse = true", chatId).Delete(&ChatCursorCache{}).Error; err != nil {
+			return err
+		}
+
+		if len(flags) > 0 {
+			if err := tx.Create(flags).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// pullMessages returns a page of the messages of the chat requested, whether
+// there are more and, if so, the cursor of the next page.
+func pullMessages(ctx context.Context, req *rpc.PullRequest) ([]*rpc.Message, bool, *int64, error) {
+	messages, err := getMessages(ctx, req)
+	if err != nil {
+		return nil, false, nil, err
+	}
+
+	limit := int(req.GetLimit())
+	hasMore := false
+	var nextCursor *int64
+	respMessages := make([]*rpc.Message, Min(limit, len(messages)))
+	for i, msg := range messages {
+		if i == limit {
+			hasMore = true
+			cursor := req.GetCursor() + int64(limit)
+			nextCursor = &cursor
+			createChatCursorCache(ctx, msg, req.GetReverse(), cursor)
+			break
+		}
+		respMessages[i] = msg.ToResponse()
+	}
+	return respMessages, hasMore, nextCursor, nil
+}
+
+// isBlocked reports whether blocker has blocked the given user.
+func isBlocked(ctx context.Context, blocker string, user string) (bool, error) {
+	if blocker == user {
+		return false, nil
+	}
+
+	var count int64
+	err := GetDatabase().WithContext(ctx).Model(&UserBlock{}).Where("blocker = ? AND blocked = ?", blocker, user).Count(&count).Error
+	return count > 0, err
+}
+
+func createChatCursorCache(ctx context.Context, msg *ChatMessage, reverse bool, cursor int64) {
 	chatCursorCache := msg.ToChatCursor(reverse, cursor)
-	if err := GetDatabase().Create(chatCursorCache).Error; err != nil {
+	if err := GetDatabase().WithContext(ctx).Create(chatCursorCache).Error; err != nil {
 		var perr *pgconn.PgError
 		errors.As(err, &perr)
 		if perr != nil && perr.Code != "23505" {
-			log.Printf("Error when creating chat cursor cache: %+v\n", err)
+			slog.ErrorCtx(ctx, "error when creating chat cursor cache", "error", err)
 		}
 	}
 }
 
-func getChatCacheCursor(req *rpc.PullRequest) (*ChatCursorCache, error) {
+func getChatCacheCursor(ctx context.Context, req *rpc.PullRequest) (*ChatCursorCache, error) {
 	if req.GetCursor() == 0 {
 		return nil, nil
 	}
 
-	db := GetDatabase()
+	db := GetDatabase().WithContext(ctx)
 	cachedCursor := new(ChatCursorCache)
+	defer observeQuery("chat_cursor_cache_lookup", time.Now())
 	if err := db.Where(
 		"chat_id = ? AND reverse = ? AND cursor = ?",
 		req.GetChat(), req.GetReverse(), req.GetCursor(),
@@ -137,19 +918,22 @@ func getChatCacheCursor(req *rpc.PullRequest) (*ChatCursorCache, error) {
 		if !errors.Is(err, gorm.ErrRecordNotFound) {
 			return cachedCursor, err
 		} else {
+			chatCursorCacheLookups.WithLabelValues("miss").Inc()
 			cachedCursor = nil
 		}
+	} else {
+		chatCursorCacheLookups.WithLabelValues("hit").Inc()
 	}
 	return cachedCursor, nil
 }
 
-func getMessages(req *rpc.PullRequest) ([]*ChatMessage, error) {
-	cachedCursor, err := getChatCacheCursor(req)
+func getMessages(ctx context.Context, req *rpc.PullRequest) ([]*ChatMessage, error) {
+	cachedCursor, err := getChatCacheCursor(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	db := GetDatabase()
+	db := GetDatabase().WithContext(ctx)
 	sortType := "ASC"
 	sortCond := ">="
 	if req.GetReverse() {
@@ -157,20 +941,26 @@ func getMessages(req *rpc.PullRequest) ([]*ChatMessage, error) {
 		sortCond = "<="
 	}
 
+	// Queries are observed whether or not they fail
 	var messages []*ChatMessage
 	limit := int(req.GetLimit())
+	start := time.Now()
 	if cachedCursor != nil {
 		queryCondition := fmt.Sprintf("chat_id = ? AND sent_at %s ?", sortCond)
-		if err := db.Where(queryCondition, req.GetChat(), cachedCursor.SentAt).Order(fmt.Sprintf("sent_at %s", sortType)).Limit(limit + 1).Find(&messages).Error; err != nil {
+		err := db.Where(queryCondition, req.GetChat(), cachedCursor.SentAt).Order(fmt.Sprintf("sent_at %s", sortType)).Limit(limit + 1).Find(&messages).Error
+		observeQuery("messages_by_cached_cursor", start)
+		if err != nil {
 			return nil, err
 		}
 	} else {
-		if err := db.Where("chat_id = ?", req.GetChat()).Order(fmt.Sprintf("sent_at %s", sortType)).Offset(int(req.GetCursor())).Limit(limit + 1).Find(&messages).Error; err != nil {
+		err := db.Where("chat_id = ?", req.GetChat()).Order(fmt.Sprintf("sent_at %s", sortType)).Offset(int(req.GetCursor())).Limit(limit + 1).Find(&messages).Error
+		observeQuery("messages_by_offset", start)
+		if err != nil {
 			return nil, err
 		}
 
 		if req.GetCursor() > 0 && len(messages) > 0 {
-			createChatCursorCache(messages[0], req.GetReverse(), req.GetCursor())
+			createChatCursorCache(ctx, messages[0], req.GetReverse(), req.GetCursor())
 		}
 	}
 
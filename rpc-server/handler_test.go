@@ -2,8 +2,8 @@ package main
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"log"
 	"math/rand"
 	"os"
 	"strings"
@@ -70,7 +70,10 @@ func checkPullResponse(tt pullTest, messages, messagesReversed []*rpc.Message) f
 		s := &IMServiceImpl{}
 		got, err := s.Pull(tt.args.ctx, tt.args.req)
 		assert.NotNil(t, got, "expected response non-nil")
-		assert.Truef(t, errors.Is(err, tt.wantErr), "expected error: %+v, got: %+v", tt.wantErr, err)
+		assert.Nil(t, err)
+		if tt.wantErr != nil {
+			assert.Contains(t, got.GetMsg(), tt.wantErr.Error())
+		}
 		assert.Truef(t, got.GetHasMore() == tt.wantHasMore, "expected hasMore: %t, got: %t", tt.wantHasMore, got.GetHasMore())
 		assert.Truef(t, len(got.GetMessages()) == tt.wantResponseLength, "expected messages length: %d, got: %d", tt.wantResponseLength, len(got.GetMessages()))
 
@@ -99,7 +102,18 @@ func checkPullResponse(tt pullTest, messages, messagesReversed []*rpc.Message) f
 
 func TestMain(m *testing.M) {
 	InitTestDatabase(sqlite.Open("file::memory:?cache=shared"))
+	// The in-memory database is dropped once its last connection closes, which
+	// cancelled queries do, so one is held open while the tests run.
+	sqlDB, err := GetDatabase().DB()
+	if err != nil {
+		log.Panicf("Could not get test database: %+v\n", err)
+	}
+	conn, err := sqlDB.Conn(context.Background())
+	if err != nil {
+		log.Panicf("Could not connect to test database: %+v\n", err)
+	}
 	exitCode := m.Run()
+	conn.Close()
 	CloseDatabase()
 	os.Exit(exitCode)
 }
@@ -208,7 +222,10 @@ func TestIMServiceImpl_Send(t *testing.T) {
 			s := &IMServiceImpl{}
 			got, err := s.Send(tt.args.ctx, tt.args.req)
 			assert.NotNil(t, got, "expected response non-nil")
-			assert.Truef(t, errors.Is(err, tt.wantErr), "expected error: %+v, got: %+v", tt.wantErr, err)
+			assert.Nil(t, err)
+			if tt.wantErr != nil {
+				assert.Contains(t, got.GetMsg(), tt.wantErr.Error())
+			}
 			assert.Truef(t, got.GetCode() == int32(tt.wantCode), "expected code %d, got: %d", tt.wantCode, got.GetCode())
 		})
 	}
@@ -559,3 +576,265 @@ func TestIMServiceImpl_Send_Pull_Normalisation(t *testing.T) {
 		t.Run(tt.name, checkPullResponse(tt, messages, messagesReversed))
 	}
 }
+
+func TestIMServiceImpl_Block(t *testing.T) {
+	s := &IMServiceImpl{}
+	ctx := context.Background()
+	send := func(sender string) *rpc.SendResponse {
+		resp, err := s.Send(ctx, &rpc.SendRequest{
+			Message: &rpc.Message{
+				Chat:     "block_a:block_b",
+				Text:     "hi",
+				Sender:   sender,
+				SendTime: GetTimeNow().UnixMicro(),
+			},
+		})
+		assert.Nil(t, err)
+		return resp
+	}
+
+	assert.Equal(t, int32(0), send("block_b").GetCode(), "expected message before block to be sent")
+
+	blockResp, err := s.Block(ctx, &rpc.BlockRequest{User: "block_a", Blocked: "block_b"})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), blockResp.GetCode())
+
+	// Blocking twice should be idempotent
+	blockResp, err = s.Block(ctx, &rpc.BlockRequest{User: "block_a", Blocked: "block_b"})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), blockResp.GetCode())
+
+	listResp, err := s.ListBlocked(ctx, &rpc.ListBlockedRequest{User: "block_a"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"block_b"}, listResp.GetBlocked())
+
+	assert.Equal(t, int32(6), send("block_b").GetCode(), "expected message from blocked sender to be rejected")
+	assert.Equal(t, int32(0), send("block_a").GetCode(), "expected blocker to still be able to send")
+
+	pullResp, err := s.Pull(ctx, &rpc.PullRequest{Chat: "block_a:block_b", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(pullResp.GetMessages()), "expected blocker to still pull historical messages")
+
+	unblockResp, err := s.Unblock(ctx, &rpc.UnblockRequest{User: "block_a", Blocked: "block_b"})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), unblockResp.GetCode())
+	assert.Equal(t, int32(0), send("block_b").GetCode(), "expected message after unblock to be sent")
+
+	listResp, err = s.ListBlocked(ctx, &rpc.ListBlockedRequest{User: "block_a"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{}, listResp.GetBlocked())
+
+	blockResp, err = s.Block(ctx, &rpc.BlockRequest{User: "block_a", Blocked: "block_a"})
+	assert.Nil(t, err)
+	assert.Contains(t, blockResp.GetMsg(), invalidUser.Error())
+	assert.Equal(t, int32(1), blockResp.GetCode())
+}
+
+func TestIMServiceImpl_Send_Encrypted(t *testing.T) {
+	moderationPipeline = NewModerationPipeline(NewBannedWordsFilter([]string{"secret"}, ModerationReject))
+	defer func() { moderationPipeline = nil }()
+
+	s := &IMServiceImpl{}
+	envelope := " secret ciphertext "
+	resp, err := s.Send(context.Background(), &rpc.SendRequest{
+		Message: &rpc.Message{
+			Chat:           "e2e_a:e2e_b",
+			Text:           envelope,
+			Sender:         "e2e_a",
+			SendTime:       GetTimeNow().UnixMicro(),
+			Encrypted:      b(true),
+			SenderKeyId:    strPtr("a1"),
+			RecipientKeyId: strPtr("b1"),
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), resp.GetCode(), "expected encrypted message to skip moderation")
+
+	pullResp, err := s.Pull(context.Background(), &rpc.PullRequest{Chat: "e2e_a:e2e_b"})
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(pullResp.GetMessages())) {
+		msg := pullResp.GetMessages()[0]
+		assert.Equal(t, envelope, msg.GetText())
+		assert.True(t, msg.GetEncrypted())
+		assert.Equal(t, "a1", msg.GetSenderKeyId())
+		assert.Equal(t, "b1", msg.GetRecipientKeyId())
+	}
+}
+
+func TestIMServiceImpl_PublishKey_GetKeys(t *testing.T) {
+	s := &IMServiceImpl{}
+	ctx := context.WithValue(context.Background(), CallerContextKey, &Caller{Service: "http-server", User: "keys_a"})
+	publish := func(user, keyId, publicKey string) (*rpc.PublishKeyResponse, error) {
+		return s.PublishKey(ctx, &rpc.PublishKeyRequest{
+			Key: &rpc.IdentityKey{User: user, KeyId: keyId, Algorithm: "x25519", PublicKey: publicKey},
+		})
+	}
+
+	resp, err := publish("keys_a", "k1", "AAEC")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), resp.GetCode())
+
+	resp, err = publish("keys_a", "k1", "AAEC")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), resp.GetCode(), "expected republishing the same key to succeed")
+
+	resp, err = publish("keys_a", "k1", "AwQF")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(8), resp.GetCode(), "expected replacing a published key to fail")
+
+	resp, err = publish("keys_a", "k2", "AwQF")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), resp.GetCode())
+
+	resp, err = publish("keys_b", "k1", "AAEC")
+	assert.Nil(t, err)
+	assert.Equal(t, permissionDeniedErr.Error(), resp.GetMsg())
+	assert.Equal(t, int32(4), resp.GetCode())
+
+	keysResp, err := s.GetKeys(context.Background(), &rpc.GetKeysRequest{User: "keys_a"})
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(keysResp.GetKeys())) {
+		assert.Equal(t, "k1", keysResp.GetKeys()[0].GetKeyId())
+		assert.Equal(t, "AAEC", keysResp.GetKeys()[0].GetPublicKey())
+		assert.Equal(t, "k2", keysResp.GetKeys()[1].GetKeyId())
+	}
+}
+
+func TestIMServiceImpl_BatchSend(t *testing.T) {
+	s := &IMServiceImpl{}
+	ctx := context.Background()
+	message := func(chat, sender, text string) *rpc.Message {
+		return &rpc.Message{Chat: chat, Text: text, Sender: sender, SendTime: GetTimeNow().UnixMicro()}
+	}
+
+	resp, err := s.BatchSend(ctx, &rpc.BatchSendRequest{Messages: []*rpc.Message{
+		message("batch_a:batch_b", "batch_a", "1"),
+		message("batch_c:batch_a", "batch_a", "2"),
+		message("batch_b:batch_a", "batch_b", "3"),
+		message("batch_a:", "batch_a", "invalid"),
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), resp.GetCode())
+	if assert.Equal(t, 4, len(resp.GetStatuses())) {
+		for i, status := range resp.GetStatuses()[:3] {
+			assert.Equalf(t, int32(0), status.GetCode(), "expected message %d to be sent", i)
+		}
+		assert.Equal(t, int32(rpc.ErrorCode_INVALID_ARGUMENT), resp.GetStatuses()[3].GetCode())
+	}
+
+	pullResp, err := s.Pull(ctx, &rpc.PullRequest{Chat: "batch_a:batch_b"})
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(pullResp.GetMessages())) {
+		assert.Equal(t, "1", pullResp.GetMessages()[0].GetText())
+		assert.Equal(t, "3", pullResp.GetMessages()[1].GetText())
+	}
+
+	resp, err = s.BatchSend(ctx, &rpc.BatchSendRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_INVALID_ARGUMENT), resp.GetCode())
+
+	callerCtx := context.WithValue(ctx, CallerContextKey, &Caller{Service: "http-server", User: "batch_a"})
+	resp, err = s.BatchSend(callerCtx, &rpc.BatchSendRequest{Messages: []*rpc.Message{
+		message("batch_a:batch_b", "batch_a", "4"),
+		message("batch_a:batch_b", "batch_b", "5"),
+	}})
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(resp.GetStatuses())) {
+		assert.Equal(t, int32(0), resp.GetStatuses()[0].GetCode())
+		assert.Equal(t, int32(rpc.ErrorCode_PERMISSION_DENIED), resp.GetStatuses()[1].GetCode())
+	}
+}
+
+func TestIMServiceImpl_MultiPull(t *testing.T) {
+	s := &IMServiceImpl{}
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		for _, chat := range []string{"multi_a:multi_b", "multi_a:multi_c"} {
+			resp, err := s.Send(ctx, &rpc.SendRequest{Message: &rpc.Message{
+				Chat:     chat,
+				Text:     fmt.Sprintf("%d", i),
+				Sender:   "multi_a",
+				SendTime: GetTimeNow().UnixMicro(),
+			}})
+			if err != nil || resp.GetCode() != 0 {
+				t.Fatalf("Error when creating test messages for multi pull test: %+v, code: %d\n", err, resp.GetCode())
+			}
+		}
+	}
+
+	resp, err := s.MultiPull(ctx, &rpc.MultiPullRequest{
+		Chats:   []string{"multi_b:multi_a", "multi_a:multi_c", "multi_a:"},
+		Limit:   2,
+		Reverse: b(true),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), resp.GetCode())
+	if assert.Equal(t, 3, len(resp.GetChats())) {
+		for _, page := range resp.GetChats()[:2] {
+			assert.Equal(t, int32(0), page.GetCode())
+			assert.True(t, page.GetHasMore())
+			assert.Equal(t, int64(2), page.GetNextCursor())
+			if assert.Equal(t, 2, len(page.GetMessages())) {
+				assert.Equal(t, "2", page.GetMessages()[0].GetText())
+			}
+		}
+		assert.Equal(t, "multi_a:multi_b", resp.GetChats()[0].GetChat())
+		assert.Equal(t, int32(rpc.ErrorCode_INVALID_ARGUMENT), resp.GetChats()[2].GetCode())
+	}
+
+	callerCtx := context.WithValue(ctx, CallerContextKey, &Caller{Service: "http-server", User: "multi_b"})
+	resp, err = s.MultiPull(callerCtx, &rpc.MultiPullRequest{Chats: []string{"multi_a:multi_b", "multi_a:multi_c"}})
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(resp.GetChats())) {
+		assert.Equal(t, 3, len(resp.GetChats()[0].GetMessages()))
+		assert.Equal(t, int32(rpc.ErrorCode_PERMISSION_DENIED), resp.GetChats()[1].GetCode())
+	}
+
+	resp, err = s.MultiPull(ctx, &rpc.MultiPullRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_INVALID_ARGUMENT), resp.GetCode())
+}
+
+func TestIMServiceImpl_ListChats(t *testing.T) {
+	s := &IMServiceImpl{}
+	ctx := context.Background()
+	for i, chat := range []string{"list_a:list_b", "list_c:list_a", "list_a:list_b", "list_b:list_c", "list_a:list_d"} {
+		resp, err := s.Send(ctx, &rpc.SendRequest{Message: &rpc.Message{
+			Chat:     chat,
+			Text:     fmt.Sprintf("%d", i),
+			Sender:   strings.Split(chat, ":")[1],
+			SendTime: GetTimeNow().UnixMicro(),
+		}})
+		if err != nil || resp.GetCode() != 0 {
+			t.Fatalf("Error when creating test messages for list chats test: %+v, code: %d\n", err, resp.GetCode())
+		}
+	}
+
+	resp, err := s.ListChats(ctx, &rpc.ListChatsRequest{User: "list_a", Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), resp.GetCode())
+	assert.True(t, resp.GetHasMore())
+	assert.Equal(t, int64(2), resp.GetNextCursor())
+	if assert.Equal(t, 2, len(resp.GetChats())) {
+		assert.Equal(t, "list_a:list_d", resp.GetChats()[0].GetChat())
+		assert.Equal(t, "list_a:list_b", resp.GetChats()[1].GetChat())
+		assert.Equal(t, int64(2), resp.GetChats()[1].GetMessageCount())
+		assert.Greater(t, resp.GetChats()[0].GetLastSendTime(), resp.GetChats()[1].GetLastSendTime())
+	}
+
+	resp, err = s.ListChats(ctx, &rpc.ListChatsRequest{User: "list_a", Cursor: 2, Limit: 2})
+	assert.Nil(t, err)
+	assert.False(t, resp.GetHasMore())
+	if assert.Equal(t, 1, len(resp.GetChats())) {
+		assert.Equal(t, "list_a:list_c", resp.GetChats()[0].GetChat())
+	}
+
+	callerCtx := context.WithValue(ctx, CallerContextKey, &Caller{Service: "http-server", User: "list_b"})
+	resp, err = s.ListChats(callerCtx, &rpc.ListChatsRequest{User: "list_a"})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_PERMISSION_DENIED), resp.GetCode())
+
+	resp, err = s.ListChats(ctx, &rpc.ListChatsRequest{User: "list_a", Limit: -1})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_INVALID_LIMIT), resp.GetCode())
+}
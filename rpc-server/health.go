@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// readinessTimeout bounds how long a readiness check waits for the database to
+// answer a ping.
+const readinessTimeout = 2 * time.Second
+
+// CheckDatabase reports whether the database is reachable.
+func CheckDatabase(ctx context.Context) error {
+	sqlDB, err := GetDatabase().DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// HealthzHandler reports that the process is alive. It does not ping the
+// database, so that a database outage takes replicas out of rotation through
+// ReadyzHandler rather than getting them all restarted.
+func HealthzHandler(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// ReadyzHandler reports whether this replica can serve requests, which requires
+// the database to be reachable and the server not to be shutting down.
+func ReadyzHandler(w http.ResponseWriter, r *http.Request) {
+	if shuttingDown.Load() {
+		writeHealth(w, http.StatusServiceUnavailable, map[string]string{"status": "shutting down"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	if err := CheckDatabase(ctx); err != nil {
+		slog.WarnCtx(ctx, "readiness check failed", "check", "database", "error", err)
+		writeHealth(w, http.StatusServiceUnavailable, map[string]string{"status": "unavailable", "database": err.Error()})
+		return
+	}
+	writeHealth(w, http.StatusOK, map[string]string{"status": "ok", "database": "ok"})
+}
+
+func writeHealth(w http.ResponseWriter, status int, body map[string]string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -13,6 +13,20 @@ import (
 type Client interface {
 	Send(ctx context.Context, req *rpc.SendRequest, callOptions ...callopt.Option) (r *rpc.SendResponse, err error)
 	Pull(ctx context.Context, req *rpc.PullRequest, callOptions ...callopt.Option) (r *rpc.PullResponse, err error)
+	Block(ctx context.Context, req *rpc.BlockRequest, callOptions ...callopt.Option) (r *rpc.BlockResponse, err error)
+	Unblock(ctx context.Context, req *rpc.UnblockRequest, callOptions ...callopt.Option) (r *rpc.UnblockResponse, err error)
+	ListBlocked(ctx context.Context, req *rpc.ListBlockedRequest, callOptions ...callopt.Option) (r *rpc.ListBlockedResponse, err error)
+	QueryAuditLog(ctx context.Context, req *rpc.QueryAuditLogRequest, callOptions ...callopt.Option) (r *rpc.QueryAuditLogResponse, err error)
+	PublishKey(ctx context.Context, req *rpc.PublishKeyRequest, callOptions ...callopt.Option) (r *rpc.PublishKeyResponse, err error)
+	GetKeys(ctx context.Context, req *rpc.GetKeysRequest, callOptions ...callopt.Option) (r *rpc.GetKeysResponse, err error)
+	BatchSend(ctx context.Context, req *rpc.BatchSendRequest, callOptions ...callopt.Option) (r *rpc.BatchSendResponse, err error)
+	MultiPull(ctx context.Context, req *rpc.MultiPullRequest, callOptions ...callopt.Option) (r *rpc.MultiPullResponse, err error)
+	ListChats(ctx context.Context, req *rpc.ListChatsRequest, callOptions ...callopt.Option) (r *rpc.ListChatsResponse, err error)
+	ExportMessages(ctx context.Context, req *rpc.ExportMessagesRequest, callOptions ...callopt.Option) (r *rpc.ExportMessagesResponse, err error)
+	ImportMessages(ctx context.Context, req *rpc.ImportMessagesRequest, callOptions ...callopt.Option) (r *rpc.ImportMessagesResponse, err error)
+	EraseUser(ctx context.Context, req *rpc.EraseUserRequest, callOptions ...callopt.Option) (r *rpc.EraseUserResponse, err error)
+	ListFlags(ctx context.Context, req *rpc.ListFlagsRequest, callOptions ...callopt.Option) (r *rpc.ListFlagsResponse, err error)
+	ReviewFlag(ctx context.Context, req *rpc.ReviewFlagRequest, callOptions ...callopt.Option) (r *rpc.ReviewFlagResponse, err error)
 }
 
 // NewClient creates a client for the service defined in IDL.
@@ -53,3 +67,73 @@ func (p *kIMServiceClient) Pull(ctx context.Context, req *rpc.PullRequest, callO
 	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
 	return p.kClient.Pull(ctx, req)
 }
+
+func (p *kIMServiceClient) Block(ctx context.Context, req *rpc.BlockRequest, callOptions ...callopt.Option) (r *rpc.BlockResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Block(ctx, req)
+}
+
+func (p *kIMServiceClient) Unblock(ctx context.Context, req *rpc.UnblockRequest, callOptions ...callopt.Option) (r *rpc.UnblockResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Unblock(ctx, req)
+}
+
+func (p *kIMServiceClient) ListBlocked(ctx context.Context, req *rpc.ListBlockedRequest, callOptions ...callopt.Option) (r *rpc.ListBlockedResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ListBlocked(ctx, req)
+}
+
+func (p *kIMServiceClient) QueryAuditLog(ctx context.Context, req *rpc.QueryAuditLogRequest, callOptions ...callopt.Option) (r *rpc.QueryAuditLogResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.QueryAuditLog(ctx, req)
+}
+
+func (p *kIMServiceClient) PublishKey(ctx context.Context, req *rpc.PublishKeyRequest, callOptions ...callopt.Option) (r *rpc.PublishKeyResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.PublishKey(ctx, req)
+}
+
+func (p *kIMServiceClient) GetKeys(ctx context.Context, req *rpc.GetKeysRequest, callOptions ...callopt.Option) (r *rpc.GetKeysResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.GetKeys(ctx, req)
+}
+
+func (p *kIMServiceClient) BatchSend(ctx context.Context, req *rpc.BatchSendRequest, callOptions ...callopt.Option) (r *rpc.BatchSendResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.BatchSend(ctx, req)
+}
+
+func (p *kIMServiceClient) MultiPull(ctx context.Context, req *rpc.MultiPullRequest, callOptions ...callopt.Option) (r *rpc.MultiPullResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.MultiPull(ctx, req)
+}
+
+func (p *kIMServiceClient) ListChats(ctx context.Context, req *rpc.ListChatsRequest, callOptions ...callopt.Option) (r *rpc.ListChatsResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ListChats(ctx, req)
+}
+
+func (p *kIMServiceClient) ExportMessages(ctx context.Context, req *rpc.ExportMessagesRequest, callOptions ...callopt.Option) (r *rpc.ExportMessagesResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ExportMessages(ctx, req)
+}
+
+func (p *kIMServiceClient) ImportMessages(ctx context.Context, req *rpc.ImportMessagesRequest, callOptions ...callopt.Option) (r *rpc.ImportMessagesResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ImportMessages(ctx, req)
+}
+
+func (p *kIMServiceClient) EraseUser(ctx context.Context, req *rpc.EraseUserRequest, callOptions ...callopt.Option) (r *rpc.EraseUserResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.EraseUser(ctx, req)
+}
+
+func (p *kIMServiceClient) ListFlags(ctx context.Context, req *rpc.ListFlagsRequest, callOptions ...callopt.Option) (r *rpc.ListFlagsResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ListFlags(ctx, req)
+}
+
+func (p *kIMServiceClient) ReviewFlag(ctx context.Context, req *rpc.ReviewFlagRequest, callOptions ...callopt.Option) (r *rpc.ReviewFlagResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ReviewFlag(ctx, req)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/utils"
+	"golang.org/x/exp/slog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// LoggingMiddleware logs the method, response code and latency of every RPC.
+func LoggingMiddleware(next endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, req, resp interface{}) error {
+		start := time.Now()
+		err := next(ctx, req, resp)
+
+		attrs := []any{
+			slog.String("method", rpcinfo.GetRPCInfo(ctx).Invocation().MethodName()),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if err != nil {
+			slog.WarnCtx(ctx, "rpc failed", append(attrs, slog.Any("error", err))...)
+			return err
+		}
+		if result, ok := resp.(utils.KitexResult); ok {
+			if response, ok := result.GetResult().(codedResponse); ok {
+				attrs = append(attrs, slog.Int("code", int(response.GetCode())))
+			}
+		}
+		slog.InfoCtx(ctx, "rpc handled", attrs...)
+		return nil
+	}
+}
+
+// gormLogger logs GORM's messages and SQL statements through slog, so that
+// statements carry the request ID of the context they were made with.
+type gormLogger struct {
+	level         logger.LogLevel
+	slowThreshold time.Duration
+}
+
+// newGormLogger returns a GORM logger at the given level: silent, error, warn
+// or info, the last of which logs every statement.
+func newGormLogger(value string) logger.Interface {
+	levels := map[string]logger.LogLevel{
+		"":       logger.Silent,
+		"silent": logger.Silent,
+		"error":  logger.Error,
+		"warn":   logger.Warn,
+		"info":   logger.Info,
+	}
+	level, ok := levels[strings.ToLower(value)]
+	if !ok {
+		panic(fmt.Sprintf("invalid database log level: %s", value))
+	}
+	return &gormLogger{level: level, slowThreshold: 200 * time.Millisecond}
+}
+
+func (l *gormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	newLogger := *l
+	newLogger.level = level
+	return &newLogger
+}
+
+func (l *gormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Info {
+		slog.InfoCtx(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *gormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Warn {
+		slog.WarnCtx(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *gormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Error {
+		slog.ErrorCtx(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= logger.Error:
+		sql, rows := fc()
+		slog.ErrorCtx(ctx, "sql failed", "sql", sql, "rows", rows, "duration", elapsed, "error", err)
+	case elapsed > l.slowThreshold && l.level >= logger.Warn:
+		sql, rows := fc()
+		slog.WarnCtx(ctx, "slow sql", "sql", sql, "rows", rows, "duration", elapsed)
+	case l.level >= logger.Info:
+		sql, rows := fc()
+		slog.InfoCtx(ctx, "sql", "sql", sql, "rows", rows, "duration", elapsed)
+	}
+}
@@ -0,0 +1,12 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGormLogger(t *testing.T) {
+	assert.NotPanics(t, func() { newGormLogger("info") })
+	assert.Panics(t, func() { newGormLogger("verbose") })
+}
@@ -1,48 +1,120 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
 	"os"
 
+	"github.com/TikTokTechImmersion/assignment_demo_2023/common/configload"
+	"github.com/TikTokTechImmersion/assignment_demo_2023/common/logging"
+	"github.com/TikTokTechImmersion/assignment_demo_2023/common/tracing"
 	rpc "github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc/imservice"
 	"github.com/cloudwego/kitex/pkg/rpcinfo"
 	"github.com/cloudwego/kitex/server"
-	etcd "github.com/kitex-contrib/registry-etcd"
+	"golang.org/x/exp/slog"
 )
 
 func main() {
+	if len(os.Args) > 1 && adminCommands[os.Args[1]] != nil {
+		os.Exit(RunAdminCommand(os.Args[1], os.Args[2:], os.Stdout))
+	}
+
+	cfg := DefaultConfig()
+	printed, err := configload.Load(cfg, os.Args, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	} else if printed {
+		return
+	}
+
+	logging.Init(cfg.Logging.Level, cfg.Logging.Format)
+
 	// Initialise connection to database
-	InitDatabase()
+	InitDatabase(cfg.Database)
 	defer CloseDatabase()
-	log.SetFlags(log.LstdFlags | log.Lmicroseconds)
+	InitRateLimiter(cfg.RateLimit)
+	InitModeration(cfg.Moderation)
+	InitAdmin(cfg.Admin)
+	relay, err := InitOutbox(cfg.Outbox)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	r, err := etcd.NewEtcdRegistry([]string{"etcd:2379"}) // r should not be reused.
+	shutdownTracing, err := tracing.Init(cfg.Server.ServiceName, cfg.Tracing.Exporter)
 	if err != nil {
 		log.Fatal(err)
 	}
+	defer shutdownTracing(context.Background())
 
-	hostname, err := os.Hostname()
+	r, err := NewRegistryFromConfig(cfg.Discovery)
 	if err != nil {
-		panic("error acquiring hostname information")
+		log.Fatal(err)
 	}
 
-	addr, err := net.ResolveTCPAddr("tcp", fmt.Sprintf("%s:8888", hostname))
+	addr, err := net.ResolveTCPAddr("tcp", cfg.Server.Addr)
 	if err != nil {
-		panic("error resolving service address")
-	} else if addr.IP.IsLoopback() {
-		panic("service address is loopback address")
-	} else if addr.IP.IsUnspecified() {
-		panic("service address is unspecified")
+		log.Fatalf("Error resolving listen address: %+v\n", err)
 	}
+	advertiseAddr, err := ResolveAdvertiseAddr(cfg.Server, cfg.Discovery)
+	if err != nil {
+		log.Fatal(err)
+	}
+	slog.Info("serving", "addr", addr.String(), "advertise_addr", advertiseAddr.String(), "discovery", cfg.Discovery.Type)
+
+	metricsServer := StartMetricsServer(cfg.Metrics)
+	shutdown := cfg.Shutdown
+	registry := &DrainingRegistry{Registry: r, AdvertiseAddr: advertiseAddr}
+	opts := []server.Option{
+		server.WithMiddleware(TracingMiddleware),
+		server.WithMiddleware(LoggingMiddleware),
+		server.WithMiddleware(MetricsMiddleware),
+		server.WithRegistry(registry),
+		server.WithExitSignal(shutdown.ExitSignal(registry)),
+		server.WithExitWaitTime(shutdown.Timeout),
+		server.WithServerBasicInfo(&rpcinfo.EndpointBasicInfo{
+			ServiceName: cfg.Server.ServiceName,
+		}),
+		server.WithServiceAddr(addr),
+	}
+
+	if mw := NewCallerVerifyMiddlewareFromConfig(cfg.S2S); mw != nil {
+		opts = append(opts, server.WithMiddleware(mw))
+	} else {
+		slog.Warn("s2s.shared_secret not set, caller verification is disabled")
+	}
+
+	svr := rpc.NewServer(new(IMServiceImpl), opts...)
 
-	svr := rpc.NewServer(new(IMServiceImpl), server.WithRegistry(r), server.WithServerBasicInfo(&rpcinfo.EndpointBasicInfo{
-		ServiceName: "demo.rpc.server",
-	}), server.WithServiceAddr(addr))
+	// The relay keeps publishing while requests drain, and events written
+	// after it stops are published once a replica starts again
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	if relay != nil {
+		slog.Info("relaying outbox events", "sink", cfg.Outbox.Sink)
+		go func() {
+			defer close(relayDone)
+			relay.Run(relayCtx)
+		}()
+	} else {
+		close(relayDone)
+	}
 
+	// Run returns once in-flight requests have drained, after which the
+	// deferred functions close the database and flush traces.
 	err = svr.Run()
 	if err != nil {
-		log.Println(err.Error())
+		slog.Error("error running server", "error", err)
+	}
+	stopRelay()
+	<-relayDone
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdown.Timeout)
+	defer cancel()
+	if err := metricsServer.Shutdown(ctx); err != nil {
+		slog.Error("error shutting down metrics server", "error", err)
 	}
+	slog.Info("server stopped")
 }
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/exp/slog"
+)
+
+var (
+	rpcRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "imservice",
+		Subsystem: "rpc_server",
+		Name:      "requests_total",
+		Help:      "Number of RPC requests handled, by method and response code.",
+	}, []string{"method", "code"})
+
+	rpcRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "imservice",
+		Subsystem: "rpc_server",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of RPC requests handled, by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	dbQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "imservice",
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Latency of database queries, by query.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"query"})
+
+	chatCursorCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "imservice",
+		Subsystem: "chat_cursor_cache",
+		Name:      "lookups_total",
+		Help:      "Number of chat cursor cache lookups, by result (hit or miss).",
+	}, []string{"result"})
+
+	outboxEventsPublished = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "imservice",
+		Subsystem: "outbox",
+		Name:      "events_published_total",
+		Help:      "Number of outbox events published to the sink.",
+	})
+
+	outboxPublishErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "imservice",
+		Subsystem: "outbox",
+		Name:      "publish_errors_total",
+		Help:      "Number of batches of outbox events the sink failed to publish.",
+	})
+)
+
+// codedResponse is implemented by every response in the IDL.
+type codedResponse interface {
+	GetCode() int32
+}
+
+// MetricsMiddleware records the count, response code and latency of every RPC.
+func MetricsMiddleware(next endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, req, resp interface{}) error {
+		start := time.Now()
+		err := next(ctx, req, resp)
+
+		method := rpcinfo.GetRPCInfo(ctx).Invocation().MethodName()
+		code := "error"
+		if err == nil {
+			if result, ok := resp.(utils.KitexResult); ok {
+				if response, ok := result.GetResult().(codedResponse); ok {
+					code = strconv.Itoa(int(response.GetCode()))
+				}
+			}
+		}
+
+		rpcRequestsTotal.WithLabelValues(method, code).Inc()
+		rpcRequestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// observeQuery records the time elapsed since start as the duration of query.
+func observeQuery(query string, start time.Time) {
+	dbQueryDuration.WithLabelValues(query).Observe(time.Since(start).Seconds())
+}
+
+// StartMetricsServer serves Prometheus metrics along with the health and
+// readiness checks in the background.
+func StartMetricsServer(cfg MetricsConfig) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", HealthzHandler)
+	mux.HandleFunc("/readyz", ReadyzHandler)
+	srv := &http.Server{Addr: cfg.Addr, Handler: mux}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("error serving metrics", "error", err)
+		}
+	}()
+	return srv
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), rpcinfo.NewRPCInfo(nil, nil, rpcinfo.NewInvocation("IMService", "MetricsTest"), nil, nil))
+	endpoint := MetricsMiddleware(func(ctx context.Context, req, resp interface{}) error {
+		result := resp.(*rpc.IMServiceSendResult)
+		if result.Success == nil {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	successResult := &rpc.IMServiceSendResult{Success: &rpc.SendResponse{Code: 0}}
+	rateLimitedResult := &rpc.IMServiceSendResult{Success: &rpc.SendResponse{Code: 5}}
+	assert.Nil(t, endpoint(ctx, nil, successResult))
+	assert.Nil(t, endpoint(ctx, nil, rateLimitedResult))
+	assert.Nil(t, endpoint(ctx, nil, rateLimitedResult))
+	assert.NotNil(t, endpoint(ctx, nil, &rpc.IMServiceSendResult{}))
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(rpcRequestsTotal.WithLabelValues("MetricsTest", "0")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(rpcRequestsTotal.WithLabelValues("MetricsTest", "5")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(rpcRequestsTotal.WithLabelValues("MetricsTest", "error")))
+}
+
+func TestChatCursorCacheMetrics(t *testing.T) {
+	s := &IMServiceImpl{}
+	for i := 0; i < 3; i++ {
+		if _, err := s.Send(context.Background(), &rpc.SendRequest{
+			Message: &rpc.Message{Chat: "metrics_a:metrics_b", Text: "hi", Sender: "metrics_a", SendTime: GetTimeNow().UnixMicro() + int64(i)},
+		}); err != nil {
+			t.Fatalf("Error when creating test messages for metrics test: %+v\n", err)
+		}
+	}
+
+	hits := testutil.ToFloat64(chatCursorCacheLookups.WithLabelValues("hit"))
+	misses := testutil.ToFloat64(chatCursorCacheLookups.WithLabelValues("miss"))
+
+	// The first page stores the cursor of the second page, which is then a hit
+	_, err := s.Pull(context.Background(), &rpc.PullRequest{Chat: "metrics_a:metrics_b", Limit: 1})
+	assert.Nil(t, err)
+	_, err = s.Pull(context.Background(), &rpc.PullRequest{Chat: "metrics_a:metrics_b", Cursor: 1, Limit: 1})
+	assert.Nil(t, err)
+	_, err = s.Pull(context.Background(), &rpc.PullRequest{Chat: "metrics_a:metrics_b", Cursor: 2, Limit: 5, Reverse: b(true)})
+	assert.Nil(t, err)
+
+	assert.Equal(t, hits+1, testutil.ToFloat64(chatCursorCacheLookups.WithLabelValues("hit")))
+	assert.Equal(t, misses+1, testutil.ToFloat64(chatCursorCacheLookups.WithLabelValues("miss")))
+}
+
+// queryCount returns how many times query has been observed.
+func queryCount(t *testing.T, query string) uint64 {
+	metric := &dto.Metric{}
+	if err := dbQueryDuration.WithLabelValues(query).(prometheus.Metric).Write(metric); err != nil {
+		t.Fatalf("Error when reading query duration metric: %+v\n", err)
+	}
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestQueryDurationMetrics(t *testing.T) {
+	s := &IMServiceImpl{}
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := s.Send(ctx, &rpc.SendRequest{
+			Message: &rpc.Message{Chat: "query_a:query_b", Text: "hi", Sender: "query_a", SendTime: GetTimeNow().UnixMicro() + int64(i)},
+		}); err != nil {
+			t.Fatalf("Error when creating test messages for metrics test: %+v\n", err)
+		}
+	}
+	byOffset, byCachedCursor := queryCount(t, "messages_by_offset"), queryCount(t, "messages_by_cached_cursor")
+
+	_, err := s.Pull(ctx, &rpc.PullRequest{Chat: "query_a:query_b", Limit: 1})
+	assert.Nil(t, err)
+	_, err = s.Pull(ctx, &rpc.PullRequest{Chat: "query_a:query_b", Cursor: 1, Limit: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, byOffset+1, queryCount(t, "messages_by_offset"))
+	assert.Equal(t, byCachedCursor+1, queryCount(t, "messages_by_cached_cursor"))
+
+	// Failed queries are observed too
+	type failQueryKey struct{}
+	db := GetDatabase()
+	if err := db.Callback().Query().Before("gorm:query").Register("test:fail_query", func(tx *gorm.DB) {
+		if tx.Statement.Context.Value(failQueryKey{}) != nil {
+			tx.AddError(errors.New("query failed"))
+		}
+	}); err != nil {
+		t.Fatalf("Error when registering failing query callback: %+v\n", err)
+	}
+	defer db.Callback().Query().Remove("test:fail_query")
+	resp, err := s.Pull(context.WithValue(ctx, failQueryKey{}, true), &rpc.PullRequest{Chat: "query_a:query_b", Limit: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(rpc.ErrorCode_INTERNAL), resp.GetCode())
+	assert.Equal(t, byOffset+2, queryCount(t, "messages_by_offset"))
+}
@@ -2,22 +2,26 @@ package main
 
 import (
 	"log"
+	"net"
 	"net/url"
-	"os"
-	"time"
+	"strconv"
 
 	"github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc"
+	"golang.org/x/exp/slog"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
 type ChatMessage struct {
-	ChatID   string `gorm:"index:chat_lookup_idx,priority:1"`
-	Sender   string
-	Receiver string
-	Text     string
-	SentAt   uint64 `gorm:"index:chat_lookup_idx,priority:2"`
+	ChatID         string `gorm:"index:chat_lookup_idx,priority:1"`
+	Sender         string `gorm:"index"`
+	Receiver       string `gorm:"index"`
+	Text           string
+	SentAt         uint64 `gorm:"index:chat_lookup_idx,priority:2"`
+	Encrypted      bool
+	SenderKeyID    string
+	RecipientKeyID string
 }
 
 type ChatCursorCache struct {
@@ -27,6 +31,41 @@ type ChatCursorCache struct {
 	SentAt  uint64
 }
 
+type FlaggedMessage struct {
+	ID       uint64 `gorm:"primaryKey"`
+	ChatID   string `gorm:"index"`
+	Sender   string
+	Text     string
+	SentAt   uint64
+	Filter   string
+	Reason   string
+	Reviewed bool `gorm:"index"`
+}
+
+type UserIdentityKey struct {
+	UserID    string `gorm:"primaryKey"`
+	KeyID     string `gorm:"primaryKey"`
+	Algorithm string
+	PublicKey string
+	CreatedAt uint64 `gorm:"autoCreateTime:false"`
+}
+
+func (key *UserIdentityKey) ToResponse() *rpc.IdentityKey {
+	return &rpc.IdentityKey{
+		User:      key.UserID,
+		KeyId:     key.KeyID,
+		Algorithm: key.Algorithm,
+		PublicKey: key.PublicKey,
+		CreatedAt: int64(key.CreatedAt),
+	}
+}
+
+type UserBlock struct {
+	Blocker   string `gorm:"primaryKey"`
+	Blocked   string `gorm:"primaryKey"`
+	CreatedAt uint64 `gorm:"autoCreateTime:false"`
+}
+
 type dbContextKeyType string
 
 var (
@@ -34,12 +73,18 @@ var (
 )
 
 func (msg *ChatMessage) ToResponse() *rpc.Message {
-	return &rpc.Message{
+	message := &rpc.Message{
 		Chat:     msg.ChatID,
 		Text:     msg.Text,
 		Sender:   msg.Sender,
 		SendTime: int64(msg.SentAt),
 	}
+	if msg.Encrypted {
+		message.Encrypted = &msg.Encrypted
+		message.SenderKeyId = &msg.SenderKeyID
+		message.RecipientKeyId = &msg.RecipientKeyID
+	}
+	return message
 }
 
 func (msg *ChatMessage) ToChatCursor(reverse bool, cursor int64) *ChatCursorCache {
@@ -53,18 +98,22 @@ func (msg *ChatMessage) ToChatCursor(reverse bool, cursor int64) *ChatCursorCach
 
 var databaseConn *gorm.DB
 
-func InitDatabase() {
-	dbUrl := constructDatabaseURL()
+func InitDatabase(cfg DatabaseConfig) {
+	dbUrl := constructDatabaseURL(cfg)
 	db, err := gorm.Open(postgres.Open(dbUrl), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
+		Logger: newGormLogger(cfg.LogLevel),
 	})
 	if err != nil {
 		log.Panicf("Could not connect to database: %+v\n", err)
 	}
+	useTracingPlugin(db)
 
-	err = db.AutoMigrate(&ChatMessage{}, &ChatCursorCache{})
+	err = db.AutoMigrate(&ChatMessage{}, &ChatCursorCache{}, &RateLimitBucket{}, &UserBlock{}, &FlaggedMessage{}, &AuditLog{}, &UserIdentityKey{}, &OutboxEvent{})
 	if err != nil {
-		log.Printf("Error migrating schemas: %+v\n", err)
+		slog.Error("error migrating schemas", "error", err)
+	}
+	if err := migrateAuditLog(db); err != nil {
+		slog.Error("error creating audit log triggers", "error", err)
 	}
 
 	sqlDB, err := db.DB()
@@ -72,9 +121,9 @@ func InitDatabase() {
 		log.Panicf("Error acquiring underlying SQL DB instance: %+v\n", err)
 	}
 
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(50)
-	sqlDB.SetConnMaxLifetime(15 * time.Minute)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 	databaseConn = db
 }
 
@@ -85,10 +134,14 @@ func InitTestDatabase(dialer gorm.Dialector) {
 	if err != nil {
 		log.Panicf("Could not connect to database: %+v\n", err)
 	}
+	useTracingPlugin(db)
 
-	err = db.AutoMigrate(&ChatMessage{}, &ChatCursorCache{})
+	err = db.AutoMigrate(&ChatMessage{}, &ChatCursorCache{}, &RateLimitBucket{}, &UserBlock{}, &FlaggedMessage{}, &AuditLog{}, &UserIdentityKey{}, &OutboxEvent{})
 	if err != nil {
-		log.Printf("Error migrating schemas: %+v\n", err)
+		slog.Error("error migrating schemas", "error", err)
+	}
+	if err := migrateAuditLog(db); err != nil {
+		slog.Error("error creating audit log triggers", "error", err)
 	}
 
 	databaseConn = db
@@ -109,37 +162,12 @@ func GetDatabase() *gorm.DB {
 	return databaseConn
 }
 
-func constructDatabaseURL() string {
-	dbUser := os.Getenv("POSTGRES_USER")
-	if dbUser == "" {
-		dbUser = "imservice"
-	}
-
-	dbPass := os.Getenv("POSTGRES_PASSWORD")
-	if dbPass == "" {
-		dbPass = "password"
-	}
-
-	dbName := os.Getenv("POSTGRES_DB")
-	if dbName == "" {
-		dbName = "imservice"
-	}
-
-	dbHost := os.Getenv("POSTGRES_HOST")
-	if dbHost == "" {
-		dbHost = "db"
-	}
-
-	dbPort := os.Getenv("POSTGRES_PORT")
-	if dbPort == "" {
-		dbPort = "5432"
-	}
-
+func constructDatabaseURL(cfg DatabaseConfig) string {
 	dbUrl := url.URL{
 		Scheme: "postgres",
-		User:   url.UserPassword(dbUser, dbPass),
-		Host:   dbHost + ":" + dbPort,
-		Path:   dbName,
+		User:   url.UserPassword(cfg.User, cfg.Password),
+		Host:   net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		Path:   cfg.Name,
 	}
 
 	return dbUrl.String()
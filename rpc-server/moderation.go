@@ -0,0 +1,340 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc"
+	"golang.org/x/exp/slog"
+)
+
+var rejectedMessageErr = errors.New("message rejected by moderation")
+
+// ModerationAction is the outcome of running a message filter.
+type ModerationAction int
+
+const (
+	ModerationAllow  ModerationAction = iota // message passes unchanged
+	ModerationFlag                           // message is sent but recorded for review
+	ModerationMask                           // offending content is replaced before the message is sent
+	ModerationReject                         // message is not sent
+)
+
+func ParseModerationAction(action string) (ModerationAction, error) {
+	switch strings.ToLower(action) {
+	case "", "off", "allow":
+		return ModerationAllow, nil
+	case "flag":
+		return ModerationFlag, nil
+	case "mask":
+		return ModerationMask, nil
+	case "reject":
+		return ModerationReject, nil
+	default:
+		return ModerationAllow, fmt.Errorf("unknown moderation action %q", action)
+	}
+}
+
+// FilterVerdict is the decision of a single filter on a message. Text holds
+// the replacement message text when Action is ModerationMask.
+type FilterVerdict struct {
+	Action ModerationAction
+	Reason string
+	Text   string
+}
+
+// MessageFilter inspects a message before it is sent. Custom classifiers hook
+// into the moderation pipeline by implementing this interface.
+type MessageFilter interface {
+	Name() string
+	Filter(ctx context.Context, message *rpc.Message) (FilterVerdict, error)
+}
+
+// EncryptedFilter is implemented by filters which do not read the text of a
+// message, such as size limits, and so also run on encrypted envelopes, which
+// the server cannot inspect. Other filters are skipped for encrypted messages.
+type EncryptedFilter interface {
+	FiltersEncrypted() bool
+}
+
+// MessageFilterFunc adapts a function into a MessageFilter.
+type MessageFilterFunc struct {
+	FilterName string
+	Func       func(ctx context.Context, message *rpc.Message) (FilterVerdict, error)
+}
+
+func (f MessageFilterFunc) Name() string {
+	return f.FilterName
+}
+
+func (f MessageFilterFunc) Filter(ctx context.Context, message *rpc.Message) (FilterVerdict, error) {
+	return f.Func(ctx, message)
+}
+
+// MaxLengthFilter rejects messages longer than Max characters.
+type MaxLengthFilter struct {
+	Max int
+}
+
+func (f *MaxLengthFilter) Name() string {
+	return "max_length"
+}
+
+func (f *MaxLengthFilter) Filter(ctx context.Context, message *rpc.Message) (FilterVerdict, error) {
+	if utf8.RuneCountInString(message.GetText()) > f.Max {
+		return FilterVerdict{Action: ModerationReject, Reason: fmt.Sprintf("message exceeds %d characters", f.Max)}, nil
+	}
+	return FilterVerdict{Action: ModerationAllow}, nil
+}
+
+// MaxBytesFilter rejects messages whose text, encrypted or not, is longer than
+// Max bytes.
+type MaxBytesFilter struct {
+	Max int
+}
+
+func (f *MaxBytesFilter) Name() string {
+	return "max_bytes"
+}
+
+func (f *MaxBytesFilter) FiltersEncrypted() bool {
+	return true
+}
+
+func (f *MaxBytesFilter) Filter(ctx context.Context, message *rpc.Message) (FilterVerdict, error) {
+	if len(message.GetText()) > f.Max {
+		return FilterVerdict{Action: ModerationReject, Reason: fmt.Sprintf("message exceeds %d bytes", f.Max)}, nil
+	}
+	return FilterVerdict{Action: ModerationAllow}, nil
+}
+
+// BannedWordsFilter matches whole words case-insensitively against a list of banned words.
+type BannedWordsFilter struct {
+	Action  ModerationAction
+	pattern *regexp.Regexp
+}
+
+func NewBannedWordsFilter(words []string, action ModerationAction) *BannedWordsFilter {
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		quoted = append(quoted, regexp.QuoteMeta(word))
+	}
+
+	filter := &BannedWordsFilter{Action: action}
+	if len(quoted) > 0 {
+		// Word boundaries are checked in matches, as \b only knows ASCII words
+		filter.pattern = regexp.MustCompile(`(?i)(` + strings.Join(quoted, "|") + `)`)
+	}
+	return filter
+}
+
+// matches returns the byte ranges of the banned words in text which are not
+// part of a longer word, treating any Unicode letter, digit or underscore as
+// a word character.
+func (f *BannedWordsFilter) matches(text string) [][]int {
+	var matches [][]int
+	for start := 0; start <= len(text); {
+		loc := f.pattern.FindStringIndex(text[start:])
+		if loc == nil {
+			break
+		}
+		loc[0], loc[1] = loc[0]+start, loc[1]+start
+
+		before, _ := utf8.DecodeLastRuneInString(text[:loc[0]])
+		after, _ := utf8.DecodeRuneInString(text[loc[1]:])
+		if loc[0] == loc[1] || isWordRune(before) || isWordRune(after) {
+			// Retry from the next rune, as a shorter banned word may start there
+			_, size := utf8.DecodeRuneInString(text[loc[0]:])
+			if size == 0 {
+				break
+			}
+			start = loc[0] + size
+			continue
+		}
+		matches = append(matches, loc)
+		start = loc[1]
+	}
+	return matches
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+// LoadBannedWords reads one banned word or phrase per line. Empty lines and
+// lines starting with '#' are ignored.
+func LoadBannedWords(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			words = append(words, line)
+		}
+	}
+	return words, scanner.Err()
+}
+
+func (f *BannedWordsFilter) Name() string {
+	return "banned_words"
+}
+
+func (f *BannedWordsFilter) Filter(ctx context.Context, message *rpc.Message) (FilterVerdict, error) {
+	if f.pattern == nil {
+		return FilterVerdict{Action: ModerationAllow}, nil
+	}
+	text := message.GetText()
+	matches := f.matches(text)
+	if len(matches) == 0 {
+		return FilterVerdict{Action: ModerationAllow}, nil
+	}
+
+	verdict := FilterVerdict{Action: f.Action, Reason: "message contains banned words"}
+	if f.Action == ModerationMask {
+		var masked strings.Builder
+		last := 0
+		for _, loc := range matches {
+			masked.WriteString(text[last:loc[0]])
+			masked.WriteString(strings.Repeat("*", utf8.RuneCountInString(text[loc[0]:loc[1]])))
+			last = loc[1]
+		}
+		masked.WriteString(text[last:])
+		verdict.Text = masked.String()
+	}
+	return verdict, nil
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\b((https?|ftp)://\S+|www\.\S+|[a-z0-9-]+(\.[a-z0-9-]+)*\.(com|net|org|io|co|me|info|biz|xyz|ly|gg)\b\S*)`)
+
+// LinkFilter detects links in messages.
+type LinkFilter struct {
+	Action ModerationAction
+}
+
+func (f *LinkFilter) Name() string {
+	return "links"
+}
+
+func (f *LinkFilter) Filter(ctx context.Context, message *rpc.Message) (FilterVerdict, error) {
+	if !linkPattern.MatchString(message.GetText()) {
+		return FilterVerdict{Action: ModerationAllow}, nil
+	}
+
+	verdict := FilterVerdict{Action: f.Action, Reason: "message contains links"}
+	if f.Action == ModerationMask {
+		verdict.Text = linkPattern.ReplaceAllString(message.GetText(), "[link removed]")
+	}
+	return verdict, nil
+}
+
+// ModerationResult is the combined outcome of all filters in a pipeline.
+type ModerationResult struct {
+	Rejected bool
+	Reason   string            // reason for rejection
+	Flags    []*FlaggedMessage // flags raised, to be recorded alongside the message
+}
+
+// ModerationPipeline runs message filters in order. Masks are applied to the
+// message before subsequent filters run, and the first rejection stops the
+// pipeline. Only EncryptedFilters run on encrypted messages.
+type ModerationPipeline struct {
+	filters []MessageFilter
+}
+
+func NewModerationPipeline(filters ...MessageFilter) *ModerationPipeline {
+	return &ModerationPipeline{filters: filters}
+}
+
+// Use appends filters to the end of the pipeline.
+func (p *ModerationPipeline) Use(filters ...MessageFilter) {
+	p.filters = append(p.filters, filters...)
+}
+
+func (p *ModerationPipeline) Moderate(ctx context.Context, message *rpc.Message) *ModerationResult {
+	result := &ModerationResult{}
+	for _, filter := range p.filters {
+		if message.GetEncrypted() {
+			if encryptedFilter, ok := filter.(EncryptedFilter); !ok || !encryptedFilter.FiltersEncrypted() {
+				continue
+			}
+		}
+
+		verdict, err := filter.Filter(ctx, message)
+		if err != nil {
+			// Fail open, a broken filter should not stop messages from being sent
+			slog.ErrorCtx(ctx, "error when running message filter", "filter", filter.Name(), "error", err)
+			continue
+		}
+
+		switch verdict.Action {
+		case ModerationReject:
+			result.Rejected = true
+			result.Reason = verdict.Reason
+			return result
+		case ModerationMask:
+			message.SetText(verdict.Text)
+		case ModerationFlag:
+			result.Flags = append(result.Flags, &FlaggedMessage{
+				Filter: filter.Name(),
+				Reason: verdict.Reason,
+			})
+		}
+	}
+	return result
+}
+
+var moderationPipeline *ModerationPipeline
+
+// InitModeration configures the moderation pipeline.
+func InitModeration(cfg ModerationConfig) {
+	pipeline := NewModerationPipeline()
+
+	if cfg.MaxBytes > 0 {
+		pipeline.Use(&MaxBytesFilter{Max: cfg.MaxBytes})
+	}
+	if cfg.MaxLength > 0 {
+		pipeline.Use(&MaxLengthFilter{Max: cfg.MaxLength})
+	}
+
+	if cfg.BannedWordsFile != "" {
+		words, err := LoadBannedWords(cfg.BannedWordsFile)
+		if err != nil {
+			log.Panicf("Error loading banned words: %+v\n", err)
+		}
+		pipeline.Use(NewBannedWordsFilter(words, mustParseModerationAction(cfg.BannedWordsAction)))
+	}
+
+	if action := mustParseModerationAction(cfg.LinksAction); action != ModerationAllow {
+		pipeline.Use(&LinkFilter{Action: action})
+	}
+
+	moderationPipeline = pipeline
+}
+
+// GetModerationPipeline returns the moderation pipeline, or nil if moderation
+// has not been initialised.
+func GetModerationPipeline() *ModerationPipeline {
+	return moderationPipeline
+}
+
+func mustParseModerationAction(value string) ModerationAction {
+	action, err := ParseModerationAction(value)
+	if err != nil {
+		log.Panicf("Invalid moderation action: %+v\n", err)
+	}
+	return action
+}
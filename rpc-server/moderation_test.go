@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModerationPipeline(t *testing.T) {
+	tests := []struct {
+		name         string
+		filters      []MessageFilter
+		text         string
+		wantRejected bool
+		wantFlags    int
+		wantText     string
+	}{
+		{
+			name:     "no filters",
+			text:     "hello there",
+			wantText: "hello there",
+		},
+		{
+			name:     "within max length",
+			filters:  []MessageFilter{&MaxLengthFilter{Max: 5}},
+			text:     "héllo",
+			wantText: "héllo",
+		},
+		{
+			name:         "exceeds max length",
+			filters:      []MessageFilter{&MaxLengthFilter{Max: 5}},
+			text:         "hello!",
+			wantRejected: true,
+		},
+		{
+			name:         "exceeds max bytes",
+			filters:      []MessageFilter{&MaxBytesFilter{Max: 5}},
+			text:         "héllo",
+			wantRejected: true,
+		},
+		{
+			name:     "banned word masked",
+			filters:  []MessageFilter{NewBannedWordsFilter([]string{"darn"}, ModerationMask)},
+			text:     "Darn it, darnation",
+			wantText: "**** it, darnation",
+		},
+		{
+			name:     "non-ASCII banned word masked",
+			filters:  []MessageFilter{NewBannedWordsFilter([]string{"дурак"}, ModerationMask)},
+			text:     "Ты ДУРАК, дураки",
+			wantText: "Ты *****, дураки",
+		},
+		{
+			name:     "banned word within non-ASCII word",
+			filters:  []MessageFilter{NewBannedWordsFilter([]string{"darn", "né"}, ModerationMask)},
+			text:     "darné né darn",
+			wantText: "darné ** ****",
+		},
+		{
+			name:         "banned word rejected",
+			filters:      []MessageFilter{NewBannedWordsFilter([]string{"darn"}, ModerationReject)},
+			text:         "darn",
+			wantRejected: true,
+		},
+		{
+			name:      "banned word flagged",
+			filters:   []MessageFilter{NewBannedWordsFilter([]string{"darn"}, ModerationFlag)},
+			text:      "darn",
+			wantFlags: 1,
+			wantText:  "darn",
+		},
+		{
+			name:     "link masked",
+			filters:  []MessageFilter{&LinkFilter{Action: ModerationMask}},
+			text:     "see https://example.com/a?b=c now",
+			wantText: "see [link removed] now",
+		},
+		{
+			name:      "bare domain flagged",
+			filters:   []MessageFilter{&LinkFilter{Action: ModerationFlag}},
+			text:      "visit example.com",
+			wantFlags: 1,
+			wantText:  "visit example.com",
+		},
+		{
+			name:     "no link",
+			filters:  []MessageFilter{&LinkFilter{Action: ModerationReject}},
+			text:     "e.g. this is fine.",
+			wantText: "e.g. this is fine.",
+		},
+		{
+			name: "mask applied before later filters",
+			filters: []MessageFilter{
+				NewBannedWordsFilter([]string{"darn"}, ModerationMask),
+				NewBannedWordsFilter([]string{"darn"}, ModerationReject),
+			},
+			text:     "darn",
+			wantText: "****",
+		},
+		{
+			name: "custom classifier and failing filter",
+			filters: []MessageFilter{
+				MessageFilterFunc{FilterName: "broken", Func: func(ctx context.Context, message *rpc.Message) (FilterVerdict, error) {
+					return FilterVerdict{}, errors.New("classifier unavailable")
+				}},
+				MessageFilterFunc{FilterName: "shouting", Func: func(ctx context.Context, message *rpc.Message) (FilterVerdict, error) {
+					return FilterVerdict{Action: ModerationFlag, Reason: "shouting"}, nil
+				}},
+			},
+			text:      "HELLO",
+			wantFlags: 1,
+			wantText:  "HELLO",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := &rpc.Message{Chat: "a:b", Sender: "a", Text: tt.text}
+			result := NewModerationPipeline(tt.filters...).Moderate(context.Background(), message)
+			assert.Equal(t, tt.wantRejected, result.Rejected)
+			assert.Equal(t, tt.wantFlags, len(result.Flags))
+			if !tt.wantRejected {
+				assert.Equal(t, tt.wantText, message.GetText())
+			}
+		})
+	}
+}
+
+func TestModerationPipeline_Encrypted(t *testing.T) {
+	pipeline := NewModerationPipeline(
+		&MaxBytesFilter{Max: 10},
+		&MaxLengthFilter{Max: 5},
+		NewBannedWordsFilter([]string{"darn"}, ModerationReject),
+	)
+	ctx := context.Background()
+
+	// Content filters are skipped for encrypted envelopes
+	message := &rpc.Message{Chat: "a:b", Sender: "a", Text: "darn darn", Encrypted: b(true)}
+	result := pipeline.Moderate(ctx, message)
+	assert.False(t, result.Rejected)
+	assert.Equal(t, "darn darn", message.GetText())
+
+	// But their size is still limited
+	result = pipeline.Moderate(ctx, &rpc.Message{Chat: "a:b", Sender: "a", Text: "0123456789a", Encrypted: b(true)})
+	assert.True(t, result.Rejected)
+	assert.Equal(t, "message exceeds 10 bytes", result.Reason)
+}
+
+func TestIMServiceImpl_Send_Moderation(t *testing.T) {
+	moderationPipeline = NewModerationPipeline(
+		&MaxLengthFilter{Max: 20},
+		NewBannedWordsFilter([]string{"darn"}, ModerationMask),
+		&LinkFilter{Action: ModerationFlag},
+	)
+	defer func() { moderationPipeline = nil }()
+
+	s := &IMServiceImpl{}
+	send := func(text string) *rpc.SendResponse {
+		resp, err := s.Send(context.Background(), &rpc.SendRequest{
+			Message: &rpc.Message{Chat: "moderation_a:moderation_b", Text: text, Sender: "moderation_a"},
+		})
+		assert.Nil(t, err)
+		return resp
+	}
+
+	assert.Equal(t, int32(7), send("this message is far too long").GetCode())
+	assert.Equal(t, int32(0), send("darn, example.com").GetCode())
+
+	var flagged []FlaggedMessage
+	if err := GetDatabase().Where("chat_id = ?", "moderation_a:moderation_b").Find(&flagged).Error; err != nil {
+		t.Fatalf("Error when retrieving flagged messages: %+v\n", err)
+	}
+	if assert.Equal(t, 1, len(flagged)) {
+		assert.Equal(t, "links", flagged[0].Filter)
+		// The flag keeps the text as sent, while the stored message is masked
+		assert.Equal(t, "darn, example.com", flagged[0].Text)
+		assert.Equal(t, "moderation_a", flagged[0].Sender)
+	}
+
+	var stored ChatMessage
+	assert.Nil(t, GetDatabase().Where("chat_id = ?", "moderation_a:moderation_b").First(&stored).Error)
+	assert.Equal(t, "****, example.com", stored.Text)
+}
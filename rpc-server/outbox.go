@@ -0,0 +1,317 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slog"
+	"gorm.io/gorm"
+)
+
+const EventTypeMessageCreated = "message.created"
+
+// outboxRelayLockID is the key of the Postgres advisory lock held by the relay
+// publishing events, so that one replica relays at a time and replicas do not
+// publish the same events concurrently.
+const outboxRelayLockID = 0x6f7574626f78
+
+// OutboxEvent is an event written in the transaction making the change it
+// describes, to be published by the OutboxRelay.
+type OutboxEvent struct {
+	ID          uint64 `gorm:"primaryKey;index:outbox_pending_idx,priority:2"`
+	Type        string
+	Key         string // chat the event is about
+	Payload     string // JSON encoded
+	CreatedAt   uint64 `gorm:"autoCreateTime:false"`
+	PublishedAt uint64 `gorm:"index:outbox_pending_idx,priority:1"` // zero until published
+}
+
+// MessageCreated is the payload of a message.created event.
+type MessageCreated struct {
+	Chat           string `json:"chat"`
+	Sender         string `json:"sender"`
+	Receiver       string `json:"receiver"`
+	Text           string `json:"text"`
+	SendTime       int64  `json:"send_time"` // unit: microseconds
+	Encrypted      bool   `json:"encrypted,omitempty"`
+	SenderKeyID    string `json:"sender_key_id,omitempty"`
+	RecipientKeyID string `json:"recipient_key_id,omitempty"`
+}
+
+// Event is an event as published to a sink. It maps onto a NATS message on the
+// subject Type, with ID as its Nats-Msg-Id header for JetStream deduplication,
+// or a Kafka record on the topic Type, keyed by Key so that the events of a
+// chat go to the same partition.
+type Event struct {
+	ID        uint64          `json:"id"`
+	Type      string          `json:"type"`
+	Key       string          `json:"key"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt int64           `json:"created_at"` // unit: microseconds
+}
+
+func (event *OutboxEvent) ToEvent() *Event {
+	return &Event{
+		ID:        event.ID,
+		Type:      event.Type,
+		Key:       event.Key,
+		Payload:   json.RawMessage(event.Payload),
+		CreatedAt: int64(event.CreatedAt),
+	}
+}
+
+// EventSink publishes events to downstream consumers.
+type EventSink interface {
+	// Publish publishes events in the order given, returning once all of them
+	// have been accepted, or an error otherwise. Events are published again
+	// after an error, and may be after success if the relay fails to record it,
+	// so consumers must deduplicate by ID.
+	Publish(ctx context.Context, events []*Event) error
+	Close() error
+}
+
+var outboxEnabled bool
+
+// InitOutbox enables writing events to the outbox and returns the relay
+// publishing them to the configured sink. Events are not written, and the
+// relay is nil, if the sink is off.
+func InitOutbox(cfg OutboxConfig) (*OutboxRelay, error) {
+	var sink EventSink
+	switch cfg.Sink {
+	case "", "off":
+		outboxEnabled = false
+		return nil, nil
+	case "file":
+		fileSink, err := NewFileSink(cfg.File)
+		if err != nil {
+			return nil, err
+		}
+		sink = fileSink
+	default:
+		return nil, fmt.Errorf("unknown outbox sink %s", cfg.Sink)
+	}
+
+	outboxEnabled = true
+	return NewOutboxRelay(GetDatabase(), sink, cfg), nil
+}
+
+// newMessageCreatedEvents returns the events recording that messages were
+// created, to be written along with them.
+func newMessageCreatedEvents(messages []*ChatMessage) ([]*OutboxEvent, error) {
+	now := uint64(GetTimeNow().UnixMicro())
+	events := make([]*OutboxEvent, len(messages))
+	for i, msg := range messages {
+		payload, err := json.Marshal(&MessageCreated{
+			Chat:           msg.ChatID,
+			Sender:         msg.Sender,
+			Receiver:       msg.Receiver,
+			Text:           msg.Text,
+			SendTime:       int64(msg.SentAt),
+			Encrypted:      msg.Encrypted,
+			SenderKeyID:    msg.SenderKeyID,
+			RecipientKeyID: msg.RecipientKeyID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		events[i] = &OutboxEvent{
+			Type:      EventTypeMessageCreated,
+			Key:       msg.ChatID,
+			Payload:   string(payload),
+			CreatedAt: now,
+		}
+	}
+	return events, nil
+}
+
+// OutboxRelay publishes the events written to the outbox to a sink, with
+// at-least-once delivery. Events are published in ID order as they become
+// visible, which is not commit order: an event of a transaction committing after
+// a later ID was published is published after it, even within a chat, so
+// consumers must not rely on the order of events, ordering messages by their
+// send time instead. Published events are kept for the retention period, then
+// deleted.
+type OutboxRelay struct {
+	db        *gorm.DB
+	sink      EventSink
+	batchSize int
+	interval  time.Duration
+	retention time.Duration
+}
+
+func NewOutboxRelay(db *gorm.DB, sink EventSink, cfg OutboxConfig) *OutboxRelay {
+	return &OutboxRelay{
+		db:        db,
+		sink:      sink,
+		batchSize: cfg.BatchSize,
+		interval:  cfg.PollInterval,
+		retention: cfg.Retention,
+	}
+}
+
+// Run publishes events every poll interval until ctx is done, then closes the
+// sink. Batches are published back to back while there is a backlog.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	defer func() {
+		if err := r.sink.Close(); err != nil {
+			slog.Error("error closing outbox sink", "error", err)
+		}
+	}()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		for {
+			published, err := r.PublishBatch(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.ErrorCtx(ctx, "error publishing outbox events", "error", err)
+				}
+				break
+			} else if published < r.batchSize {
+				break
+			}
+		}
+
+		if err := r.Prune(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorCtx(ctx, "error pruning outbox events", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PublishBatch publishes the committed unpublished events with the lowest IDs,
+// up to the batch size, returning how many were published. Events are only marked as published once
+// the sink has accepted them, in the transaction holding the relay lock, so
+// events are published again if either fails. No events are published if the
+// relay of another replica holds the lock.
+func (r *OutboxRelay) PublishBatch(ctx context.Context) (int, error) {
+	published := 0
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			var locked bool
+			if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", outboxRelayLockID).Scan(&locked).Error; err != nil || !locked {
+				return err
+			}
+		}
+
+		var pending []*OutboxEvent
+		start := time.Now()
+		err := tx.Where("published_at = 0").Order("id ASC").Limit(r.batchSize).Find(&pending).Error
+		observeQuery("outbox_pending", start)
+		if err != nil || len(pending) == 0 {
+			return err
+		}
+
+		events := make([]*Event, len(pending))
+		ids := make([]uint64, len(pending))
+		for i, event := range pending {
+			events[i] = event.ToEvent()
+			ids[i] = event.ID
+		}
+		if err := r.sink.Publish(ctx, events); err != nil {
+			outboxPublishErrors.Inc()
+			return err
+		}
+
+		published = len(pending)
+		return tx.Model(&OutboxEvent{}).Where("id IN ?", ids).Update("published_at", GetTimeNow().UnixMicro()).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	outboxEventsPublished.Add(float64(published))
+	return published, nil
+}
+
+// Prune deletes the events published longer than the retention period ago,
+// unless it is zero.
+func (r *OutboxRelay) Prune(ctx context.Context) error {
+	if r.retention <= 0 {
+		return nil
+	}
+	cutoff := GetTimeNow().Add(-r.retention).UnixMicro()
+	return r.db.WithContext(ctx).Where("published_at > 0 AND published_at < ?", cutoff).Delete(&OutboxEvent{}).Error
+}
+
+// FileSink appends events to a file as JSON Lines, syncing it after every
+// batch.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{file: file}, nil
+}
+
+func (s *FileSink) Publish(ctx context.Context, events []*Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w := bufio.NewWriter(s.file)
+	encoder := json.NewEncoder(w)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+// MemorySink keeps the events published in memory, for tests.
+type MemorySink struct {
+	mu     sync.Mutex
+	events []*Event
+	err    error
+}
+
+// SetErr makes Publish fail with err, or succeed again if nil.
+func (s *MemorySink) SetErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+func (s *MemorySink) Publish(ctx context.Context, events []*Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, events...)
+	return nil
+}
+
+// Events returns the events published so far, in order.
+func (s *MemorySink) Events() []*Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Event(nil), s.events...)
+}
+
+func (s *MemorySink) Close() error {
+	return nil
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// drainOutbox publishes the events other tests left in the outbox.
+func drainOutbox(t *testing.T) {
+	relay := NewOutboxRelay(GetDatabase(), &MemorySink{}, OutboxConfig{BatchSize: 100})
+	for {
+		published, err := relay.PublishBatch(context.Background())
+		if err != nil {
+			t.Fatalf("Error when draining outbox: %+v\n", err)
+		} else if published == 0 {
+			return
+		}
+	}
+}
+
+func TestIMServiceImpl_Send_Outbox(t *testing.T) {
+	s := &IMServiceImpl{}
+	ctx := context.Background()
+	countEvents := func() int64 {
+		var count int64
+		GetDatabase().Model(&OutboxEvent{}).Where("key = ?", "outbox_a:outbox_b").Count(&count)
+		return count
+	}
+
+	resp, err := s.Send(ctx, &rpc.SendRequest{Message: &rpc.Message{Chat: "outbox_b:outbox_a", Text: "off", Sender: "outbox_a", SendTime: 1}})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), resp.GetCode())
+	assert.Equal(t, int64(0), countEvents(), "expected no events with the outbox off")
+
+	outboxEnabled = true
+	defer func() { outboxEnabled = false }()
+	resp, err = s.Send(ctx, &rpc.SendRequest{Message: &rpc.Message{Chat: "outbox_b:outbox_a", Text: "hi", Sender: "outbox_a", SendTime: 2}})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), resp.GetCode())
+	batchResp, err := s.BatchSend(ctx, &rpc.BatchSendRequest{Messages: []*rpc.Message{
+		{Chat: "outbox_a:outbox_b", Text: "one", Sender: "outbox_b", SendTime: 3},
+		{Chat: "outbox_a:outbox_b", Text: "two", Sender: "outbox_b", SendTime: 4},
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), batchResp.GetCode())
+
+	var events []*OutboxEvent
+	GetDatabase().Where("key = ?", "outbox_a:outbox_b").Order("id ASC").Find(&events)
+	if assert.Equal(t, 3, len(events)) {
+		assert.Equal(t, EventTypeMessageCreated, events[0].Type)
+		assert.Equal(t, uint64(0), events[0].PublishedAt)
+		payload := new(MessageCreated)
+		assert.Nil(t, json.Unmarshal([]byte(events[0].Payload), payload))
+		assert.Equal(t, MessageCreated{Chat: "outbox_a:outbox_b", Sender: "outbox_a", Receiver: "outbox_b", Text: "hi", SendTime: 2}, *payload)
+		assert.Nil(t, json.Unmarshal([]byte(events[2].Payload), payload))
+		assert.Equal(t, "two", payload.Text)
+	}
+}
+
+func TestImportMessages_Outbox(t *testing.T) {
+	ctx := context.WithValue(context.Background(), CallerContextKey, cliCaller)
+	outboxEnabled = true
+	defer func() { outboxEnabled = false }()
+
+	// Only the messages stored produce events, not the duplicates skipped
+	export := `{"chat_id": "outboximport_a:outboximport_b", "sender": "outboximport_a", "receiver": "outboximport_b", "text": "hi", "sent_at": 1000}
+{"chat_id": "outboximport_a:outboximport_b", "sender": "outboximport_a", "receiver": "outboximport_b", "text": "hi", "sent_at": 1000}
+`
+	imported, skipped, err := ReadImport(ctx, strings.NewReader(export), 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, imported)
+	assert.Equal(t, 1, skipped)
+
+	var events []*OutboxEvent
+	GetDatabase().Where("key = ?", "outboximport_a:outboximport_b").Find(&events)
+	if assert.Equal(t, 1, len(events)) {
+		var payload MessageCreated
+		assert.Nil(t, json.Unmarshal([]byte(events[0].Payload), &payload))
+		assert.Equal(t, "hi", payload.Text)
+		assert.Equal(t, int64(1000), payload.SendTime)
+	}
+}
+
+func TestOutboxRelay_PublishBatch(t *testing.T) {
+	drainOutbox(t)
+	outboxEnabled = true
+	defer func() { outboxEnabled = false }()
+	s := &IMServiceImpl{}
+	ctx := context.Background()
+	for _, text := range []string{"1", "2", "3"} {
+		if resp, err := s.Send(ctx, &rpc.SendRequest{Message: &rpc.Message{Chat: "relay_a:relay_b", Text: text, Sender: "relay_a", SendTime: 1}}); err != nil || resp.GetCode() != 0 {
+			t.Fatalf("Error when creating test messages for relay test: %+v, code: %d\n", err, resp.GetCode())
+		}
+	}
+
+	sink := &MemorySink{}
+	relay := NewOutboxRelay(GetDatabase(), sink, OutboxConfig{BatchSize: 2})
+
+	// Events are not marked as published when the sink fails, and are published
+	// again once it recovers
+	sink.SetErr(errors.New("broker unavailable"))
+	published, err := relay.PublishBatch(ctx)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, published)
+	sink.SetErr(nil)
+
+	published, err = relay.PublishBatch(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, published)
+	published, err = relay.PublishBatch(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, published)
+	published, err = relay.PublishBatch(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, published)
+
+	events := sink.Events()
+	if assert.Equal(t, 3, len(events)) {
+		for i, text := range []string{"1", "2", "3"} {
+			payload := new(MessageCreated)
+			assert.Nil(t, json.Unmarshal(events[i].Payload, payload))
+			assert.Equal(t, text, payload.Text)
+			assert.Equal(t, "relay_a:relay_b", events[i].Key)
+		}
+		assert.Less(t, events[0].ID, events[1].ID)
+	}
+
+	var pending int64
+	GetDatabase().Model(&OutboxEvent{}).Where("published_at = 0").Count(&pending)
+	assert.Equal(t, int64(0), pending)
+}
+
+func TestOutboxRelay_Run(t *testing.T) {
+	drainOutbox(t)
+	outboxEnabled = true
+	defer func() { outboxEnabled = false }()
+	s := &IMServiceImpl{}
+
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	sink, err := NewFileSink(path)
+	if err != nil {
+		t.Fatalf("Error when creating file sink: %+v\n", err)
+	}
+	// The message is sent before the relay starts, as the shared in-memory
+	// database locks tables written concurrently.
+	if resp, err := s.Send(context.Background(), &rpc.SendRequest{Message: &rpc.Message{Chat: "run_a:run_b", Text: "hi", Sender: "run_b", SendTime: 1}}); err != nil || resp.GetCode() != 0 {
+		t.Fatalf("Error when creating test message for relay test: %+v, code: %d\n", err, resp.GetCode())
+	}
+
+	relay := NewOutboxRelay(GetDatabase(), sink, OutboxConfig{BatchSize: 10, PollInterval: 10 * time.Millisecond, Retention: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		relay.Run(ctx)
+	}()
+
+	var lines []string
+	for start := time.Now(); len(lines) == 0 && time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		data, _ := os.ReadFile(path)
+		lines = strings.Split(strings.TrimSpace(string(data)), "\n")
+		if lines[0] == "" {
+			lines = nil
+		}
+	}
+	cancel()
+	<-done
+
+	if assert.Equal(t, 1, len(lines)) {
+		event := new(Event)
+		assert.Nil(t, json.Unmarshal([]byte(lines[0]), event))
+		assert.Equal(t, EventTypeMessageCreated, event.Type)
+		assert.Equal(t, "run_a:run_b", event.Key)
+		assert.Contains(t, string(event.Payload), `"text":"hi"`)
+	}
+}
+
+func TestOutboxRelay_Prune(t *testing.T) {
+	db := GetDatabase()
+	now := uint64(GetTimeNow().UnixMicro())
+	for _, event := range []*OutboxEvent{
+		{Type: "prune.test", PublishedAt: now - uint64(2*time.Hour/time.Microsecond)},
+		{Type: "prune.test", PublishedAt: now - uint64(time.Minute/time.Microsecond)},
+		{Type: "prune.test"},
+	} {
+		if err := db.Create(event).Error; err != nil {
+			t.Fatalf("Error when creating test events for prune test: %+v\n", err)
+		}
+	}
+	// Deleted afterwards so that the relays of other tests do not publish them
+	defer db.Where("type = ?", "prune.test").Delete(&OutboxEvent{})
+
+	relay := NewOutboxRelay(db, &MemorySink{}, OutboxConfig{Retention: time.Hour})
+	assert.Nil(t, relay.Prune(context.Background()))
+
+	var remaining []*OutboxEvent
+	db.Where("type = ?", "prune.test").Order("id ASC").Find(&remaining)
+	if assert.Equal(t, 2, len(remaining)) {
+		assert.NotZero(t, remaining[0].PublishedAt)
+		assert.Zero(t, remaining[1].PublishedAt)
+	}
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/exp/slog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var rateLimitedErr = errors.New("rate limited")
+
+// RateLimit describes a token bucket which refills at Rate tokens per second
+// and holds at most Burst tokens.
+type RateLimit struct {
+	Rate  float64
+	Burst float64
+}
+
+// Bucket is a token bucket identified by key, limited by Limit.
+type Bucket struct {
+	Key   string
+	Limit RateLimit
+}
+
+// RateLimiter takes a token from each of a set of buckets, either from all of
+// them or, if any of them is empty, from none. If no token is taken, it
+// reports how long the caller should wait before retrying.
+type RateLimiter interface {
+	Take(ctx context.Context, buckets []Bucket) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// rateLimitPruneInterval is how often full buckets, which are the same as
+// buckets not yet created, are deleted.
+const rateLimitPruneInterval = time.Minute
+
+// refillTokens returns the tokens a bucket holding tokens, which was last
+// updated at updatedAt, holds at now.
+func refillTokens(tokens float64, updatedAt, now time.Time, limit RateLimit) float64 {
+	if elapsed := now.Sub(updatedAt).Seconds(); elapsed > 0 {
+		tokens = math.Min(limit.Burst, tokens+elapsed*limit.Rate)
+	}
+	return tokens
+}
+
+// takeTokens takes a single token from each of the refilled buckets holding
+// tokens if all of them hold one, and otherwise returns how long until they do.
+func takeTokens(tokens []float64, buckets []Bucket) (bool, time.Duration) {
+	var retryAfter time.Duration
+	for i := range tokens {
+		if tokens[i] < 1 {
+			if wait := time.Duration((1 - tokens[i]) / buckets[i].Limit.Rate * float64(time.Second)); wait > retryAfter {
+				retryAfter = wait
+			}
+		}
+	}
+	if retryAfter > 0 {
+		return false, retryAfter
+	}
+
+	for i := range tokens {
+		tokens[i]--
+	}
+	return true, 0
+}
+
+// takeToken refills a bucket holding tokens which was last updated at
+// updatedAt and attempts to take a single token from it.
+func takeToken(tokens float64, updatedAt, now time.Time, limit RateLimit) (float64, bool, time.Duration) {
+	refilled := []float64{refillTokens(tokens, updatedAt, now, limit)}
+	allowed, retryAfter := takeTokens(refilled, []Bucket{{Limit: limit}})
+	return refilled[0], allowed, retryAfter
+}
+
+// fullAt returns when a bucket holding tokens at now refills to its burst.
+func fullAt(tokens float64, now time.Time, limit RateLimit) time.Time {
+	return now.Add(time.Duration((limit.Burst - tokens) / limit.Rate * float64(time.Second)))
+}
+
+// MemoryRateLimiter keeps buckets in process memory. Budgets are not shared
+// between replicas, so it is meant for single instance deployments and tests.
+type MemoryRateLimiter struct {
+	mu       sync.Mutex
+	buckets  map[string]*memoryBucket
+	prunedAt time.Time
+}
+
+type memoryBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time
+}
+
+func NewMemoryRateLimiter() *MemoryRateLimiter {
+	return &MemoryRateLimiter{buckets: make(map[string]*memoryBucket), prunedAt: GetTimeNow()}
+}
+
+func (l *MemoryRateLimiter) Take(ctx context.Context, buckets []Bucket) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := GetTimeNow()
+	if now.Sub(l.prunedAt) >= rateLimitPruneInterval {
+		l.prune(now)
+	}
+
+	states := make([]*memoryBucket, len(buckets))
+	tokens := make([]float64, len(buckets))
+	for i, bucket := range buckets {
+		state, ok := l.buckets[bucket.Key]
+		if !ok {
+			state = &memoryBucket{tokens: bucket.Limit.Burst, updatedAt: now}
+			l.buckets[bucket.Key] = state
+		}
+		states[i] = state
+		tokens[i] = refillTokens(state.tokens, state.updatedAt, now, bucket.Limit)
+	}
+
+	allowed, retryAfter := takeTokens(tokens, buckets)
+	for i, state := range states {
+		state.tokens, state.updatedAt = tokens[i], now
+		state.fullAt = fullAt(tokens[i], now, buckets[i].Limit)
+	}
+	return allowed, retryAfter, nil
+}
+
+// prune deletes the buckets which have refilled by now, so that memory does
+// not grow with every sender and chat ever limited.
+func (l *MemoryRateLimiter) prune(now time.Time) {
+	for key, state := range l.buckets {
+		if !now.Before(state.fullAt) {
+			delete(l.buckets, key)
+		}
+	}
+	l.prunedAt = now
+}
+
+// RateLimitBucket is the database representation of a token bucket.
+type RateLimitBucket struct {
+	Key       string `gorm:"primaryKey"`
+	Tokens    float64
+	UpdatedAt int64 `gorm:"autoUpdateTime:false"` // unit: microseconds
+	FullAt    int64 `gorm:"index"`                // unit: microseconds, when the bucket refills to its burst
+}
+
+// DatabaseRateLimiter keeps buckets in the service database, sharing budgets
+// between all replicas. Buckets are locked for the duration of the update, and
+// deleted once they have refilled.
+type DatabaseRateLimiter struct {
+	db *gorm.DB
+
+	mu       sync.Mutex
+	prunedAt time.Time
+}
+
+func NewDatabaseRateLimiter(db *gorm.DB) *DatabaseRateLimiter {
+	return &DatabaseRateLimiter{db: db, prunedAt: GetTimeNow()}
+}
+
+func (l *DatabaseRateLimiter) Take(ctx context.Context, buckets []Bucket) (bool, time.Duration, error) {
+	// Buckets are locked in key order so that concurrent takes cannot deadlock
+	buckets = append([]Bucket(nil), buckets...)
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Key < buckets[j].Key })
+
+	var allowed bool
+	var retryAfter time.Duration
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := GetTimeNow()
+		rows := make([]*RateLimitBucket, len(buckets))
+		tokens := make([]float64, len(buckets))
+		for i, bucket := range buckets {
+			// Creating the bucket, or updating it if it exists, locks its row,
+			// which a concurrent prune can then not delete
+			row := &RateLimitBucket{Key: bucket.Key, Tokens: bucket.Limit.Burst, UpdatedAt: now.UnixMicro()}
+			upsert := clause.OnConflict{Columns: []clause.Column{{Name: "key"}}, DoUpdates: clause.AssignmentColumns([]string{"key"})}
+			if err := tx.Clauses(upsert).Create(row).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("key = ?", bucket.Key).First(row).Error; err != nil {
+				return err
+			}
+			rows[i] = row
+			tokens[i] = refillTokens(row.Tokens, time.UnixMicro(row.UpdatedAt), now, bucket.Limit)
+		}
+
+		allowed, retryAfter = takeTokens(tokens, buckets)
+		for i, row := range rows {
+			row.Tokens, row.UpdatedAt = tokens[i], now.UnixMicro()
+			row.FullAt = fullAt(tokens[i], now, buckets[i].Limit).UnixMicro()
+			if err := tx.Save(row).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return false, 0, err
+	}
+
+	if err := l.maybePrune(ctx); err != nil {
+		slog.ErrorCtx(ctx, "error when pruning rate limit buckets", "error", err)
+	}
+	return allowed, retryAfter, nil
+}
+
+// maybePrune deletes the buckets which have refilled, at most once per prune
+// interval.
+func (l *DatabaseRateLimiter) maybePrune(ctx context.Context) error {
+	l.mu.Lock()
+	now := GetTimeNow()
+	if now.Sub(l.prunedAt) < rateLimitPruneInterval {
+		l.mu.Unlock()
+		return nil
+	}
+	l.prunedAt = now
+	l.mu.Unlock()
+	return l.prune(ctx, now)
+}
+
+func (l *DatabaseRateLimiter) prune(ctx context.Context, now time.Time) error {
+	return l.db.WithContext(ctx).Where("full_at <= ?", now.UnixMicro()).Delete(&RateLimitBucket{}).Error
+}
+
+// redisTakeTokenScript implements takeTokens atomically within Redis. ARGV
+// holds the current time, then the rate and burst of each bucket in KEYS.
+var redisTakeTokenScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local tokens = {}
+local allowed = 1
+local retry_after = 0
+for i, key in ipairs(KEYS) do
+	local rate = tonumber(ARGV[2 * i])
+	local burst = tonumber(ARGV[2 * i + 1])
+	local bucket = redis.call("HMGET", key, "tokens", "updated_at")
+	tokens[i] = tonumber(bucket[1]) or burst
+	local updated_at = tonumber(bucket[2]) or now
+	if now > updated_at then
+		tokens[i] = math.min(burst, tokens[i] + (now - updated_at) / 1000000 * rate)
+	end
+	if tokens[i] < 1 then
+		allowed = 0
+		retry_after = math.max(retry_after, math.ceil((1 - tokens[i]) / rate * 1000000))
+	end
+end
+for i, key in ipairs(KEYS) do
+	local rate = tonumber(ARGV[2 * i])
+	local burst = tonumber(ARGV[2 * i + 1])
+	if allowed == 1 then
+		tokens[i] = tokens[i] - 1
+	end
+	redis.call("HSET", key, "tokens", tostring(tokens[i]), "updated_at", tostring(now))
+	redis.call("PEXPIRE", key, math.ceil(burst / rate * 1000) + 1000)
+end
+return {allowed, retry_after}
+`)
+
+// RedisRateLimiter keeps buckets in Redis, sharing budgets between all
+// replicas. Buckets expire once they have refilled.
+type RedisRateLimiter struct {
+	client redis.UniversalClient
+}
+
+func NewRedisRateLimiter(client redis.UniversalClient) *RedisRateLimiter {
+	return &RedisRateLimiter{client: client}
+}
+
+func (l *RedisRateLimiter) Take(ctx context.Context, buckets []Bucket) (bool, time.Duration, error) {
+	keys := make([]string, len(buckets))
+	args := []interface{}{GetTimeNow().UnixMicro()}
+	for i, bucket := range buckets {
+		keys[i] = "ratelimit:" + bucket.Key
+		args = append(args, bucket.Limit.Rate, bucket.Limit.Burst)
+	}
+	result, err := redisTakeTokenScript.Run(ctx, l.client, keys, args...).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	return result[0] == 1, time.Duration(result[1]) * time.Microsecond, nil
+}
+
+// SendRateLimiter enforces the per-sender and per-chat budgets of Send.
+type SendRateLimiter struct {
+	limiter     RateLimiter
+	senderLimit RateLimit
+	chatLimit   RateLimit
+}
+
+// Allow takes a token from both the sender's and the chat's buckets, or from
+// neither if either is empty, so that rejected messages do not use up the
+// budget of the other. Buckets with a non-positive rate are not limited.
+func (l *SendRateLimiter) Allow(ctx context.Context, sender string, chatId string) (bool, time.Duration, error) {
+	var buckets []Bucket
+	if l.senderLimit.Rate > 0 {
+		buckets = append(buckets, Bucket{Key: "sender:" + sender, Limit: l.senderLimit})
+	}
+	if l.chatLimit.Rate > 0 {
+		buckets = append(buckets, Bucket{Key: "chat:" + chatId, Limit: l.chatLimit})
+	}
+	if len(buckets) == 0 {
+		return true, 0, nil
+	}
+	return l.limiter.Take(ctx, buckets)
+}
+
+var sendRateLimiter *SendRateLimiter
+
+// InitRateLimiter configures the Send rate limiter. Rate limiting is disabled
+// if the backend is off.
+func InitRateLimiter(cfg RateLimitConfig) {
+	var limiter RateLimiter
+	switch cfg.Backend {
+	case "", "off":
+		return
+	case "memory":
+		limiter = NewMemoryRateLimiter()
+	case "postgres":
+		limiter = NewDatabaseRateLimiter(GetDatabase())
+	case "redis":
+		limiter = NewRedisRateLimiter(redis.NewClient(&redis.Options{
+			Addr:     cfg.RedisAddr,
+			Password: cfg.RedisPassword,
+		}))
+	default:
+		log.Panicf("Unknown rate limit backend: %s\n", cfg.Backend)
+	}
+
+	sendRateLimiter = &SendRateLimiter{
+		limiter:     limiter,
+		senderLimit: RateLimit{Rate: cfg.SenderRate, Burst: cfg.SenderBurst},
+		chatLimit:   RateLimit{Rate: cfg.ChatRate, Burst: cfg.ChatBurst},
+	}
+}
+
+// GetRateLimiter returns the Send rate limiter, or nil if rate limiting is disabled.
+func GetRateLimiter() *SendRateLimiter {
+	return sendRateLimiter
+}
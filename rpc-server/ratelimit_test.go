@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTakeToken(t *testing.T) {
+	now := GetTimeNow()
+	limit := RateLimit{Rate: 2, Burst: 4}
+	tests := []struct {
+		name           string
+		tokens         float64
+		updatedAt      time.Time
+		wantTokens     float64
+		wantAllowed    bool
+		wantRetryAfter time.Duration
+	}{
+		{"full bucket", 4, now, 3, true, 0},
+		{"last token", 1, now, 0, true, 0},
+		{"empty bucket", 0, now, 0, false, 500 * time.Millisecond},
+		{"half token", 0.5, now, 0.5, false, 250 * time.Millisecond},
+		{"refilled bucket", 0, now.Add(-time.Second), 1, true, 0},
+		{"refill capped at burst", 0, now.Add(-time.Hour), 3, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, allowed, retryAfter := takeToken(tt.tokens, tt.updatedAt, now, limit)
+			assert.InDelta(t, tt.wantTokens, tokens, 1e-9)
+			assert.Equal(t, tt.wantAllowed, allowed)
+			assert.Equal(t, tt.wantRetryAfter, retryAfter)
+		})
+	}
+}
+
+func TestRateLimiters(t *testing.T) {
+	limiters := map[string]RateLimiter{
+		"memory":   NewMemoryRateLimiter(),
+		"database": NewDatabaseRateLimiter(GetDatabase()),
+	}
+
+	for name, limiter := range limiters {
+		t.Run(name, func(t *testing.T) {
+			limit := RateLimit{Rate: 0.001, Burst: 3}
+			for i := 0; i < 3; i++ {
+				allowed, _, err := limiter.Take(context.Background(), []Bucket{{Key: name + "_key", Limit: limit}})
+				assert.Nil(t, err)
+				assert.Truef(t, allowed, "expected request %d to be allowed", i)
+			}
+
+			allowed, retryAfter, err := limiter.Take(context.Background(), []Bucket{{Key: name + "_key", Limit: limit}})
+			assert.Nil(t, err)
+			assert.False(t, allowed, "expected request over burst to be limited")
+			assert.True(t, retryAfter > 0, "expected positive retry after")
+
+			allowed, _, err = limiter.Take(context.Background(), []Bucket{{Key: name + "_other_key", Limit: limit}})
+			assert.Nil(t, err)
+			assert.True(t, allowed, "expected buckets to be independent")
+
+			// No token is taken from any bucket if one of them is empty
+			allowed, _, err = limiter.Take(context.Background(), []Bucket{{Key: name + "_other_key", Limit: limit}, {Key: name + "_key", Limit: limit}})
+			assert.Nil(t, err)
+			assert.False(t, allowed, "expected request to be limited by the empty bucket")
+			for i := 0; i < 2; i++ {
+				allowed, _, err = limiter.Take(context.Background(), []Bucket{{Key: name + "_other_key", Limit: limit}})
+				assert.Nil(t, err)
+				assert.Truef(t, allowed, "expected request %d to be allowed by the bucket left untouched", i)
+			}
+		})
+	}
+}
+
+func TestSendRateLimiter_Allow(t *testing.T) {
+	limiter := &SendRateLimiter{
+		limiter:     NewMemoryRateLimiter(),
+		senderLimit: RateLimit{Rate: 0.001, Burst: 2},
+		chatLimit:   RateLimit{Rate: 0.001, Burst: 1},
+	}
+	ctx := context.Background()
+
+	allowed, _, err := limiter.Allow(ctx, "a", "a:b")
+	assert.Nil(t, err)
+	assert.True(t, allowed)
+
+	// Rejected by the chat's bucket without taking from the sender's
+	allowed, _, err = limiter.Allow(ctx, "a", "a:b")
+	assert.Nil(t, err)
+	assert.False(t, allowed)
+	allowed, _, err = limiter.Allow(ctx, "a", "a:c")
+	assert.Nil(t, err)
+	assert.True(t, allowed)
+
+	allowed, _, err = limiter.Allow(ctx, "a", "a:d")
+	assert.Nil(t, err)
+	assert.False(t, allowed)
+
+	allowed, _, err = (&SendRateLimiter{limiter: NewMemoryRateLimiter()}).Allow(ctx, "a", "a:b")
+	assert.Nil(t, err)
+	assert.True(t, allowed, "expected no limit with zero rates")
+}
+
+func TestRateLimiters_Prune(t *testing.T) {
+	ctx := context.Background()
+	limit := RateLimit{Rate: 1000, Burst: 1}
+	slow := RateLimit{Rate: 0.001, Burst: 1}
+
+	memory := NewMemoryRateLimiter()
+	_, _, err := memory.Take(ctx, []Bucket{{Key: "prune_full", Limit: limit}, {Key: "prune_empty", Limit: slow}})
+	assert.Nil(t, err)
+	memory.prune(GetTimeNow().Add(time.Second))
+	assert.Equal(t, 1, len(memory.buckets))
+	assert.Contains(t, memory.buckets, "prune_empty")
+
+	db := GetDatabase()
+	database := NewDatabaseRateLimiter(db)
+	_, _, err = database.Take(ctx, []Bucket{{Key: "prune_full", Limit: limit}, {Key: "prune_empty", Limit: slow}})
+	assert.Nil(t, err)
+	assert.Nil(t, database.prune(ctx, GetTimeNow().Add(time.Second)))
+	var keys []string
+	db.Model(&RateLimitBucket{}).Where("key LIKE ?", "prune_%").Pluck("key", &keys)
+	assert.Equal(t, []string{"prune_empty"}, keys)
+
+	// A pruned bucket starts full again
+	allowed, _, err := database.Take(ctx, []Bucket{{Key: "prune_full", Limit: limit}})
+	assert.Nil(t, err)
+	assert.True(t, allowed)
+}
+
+func TestIMServiceImpl_Send_RateLimited(t *testing.T) {
+	sendRateLimiter = &SendRateLimiter{
+		limiter:     NewMemoryRateLimiter(),
+		senderLimit: RateLimit{Rate: 0.001, Burst: 2},
+	}
+	defer func() { sendRateLimiter = nil }()
+
+	s := &IMServiceImpl{}
+	req := func() *rpc.SendRequest {
+		return &rpc.SendRequest{Message: &rpc.Message{Chat: "ratelimit_a:ratelimit_b", Text: "hi", Sender: "ratelimit_a"}}
+	}
+	for i := 0; i < 2; i++ {
+		resp, err := s.Send(context.Background(), req())
+		assert.Nil(t, err)
+		assert.Equal(t, int32(0), resp.GetCode())
+		assert.False(t, resp.IsSetRetryAfter())
+	}
+
+	resp, err := s.Send(context.Background(), req())
+	assert.Nil(t, err, "expected rate limited response to be returned without error")
+	assert.Equal(t, int32(5), resp.GetCode())
+	assert.Equal(t, rateLimitedErr.Error(), resp.GetMsg())
+	assert.True(t, resp.GetRetryAfter() > 0, "expected positive retry after")
+}
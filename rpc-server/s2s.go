@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/common/s2s"
+	"github.com/bytedance/gopkg/cloud/metainfo"
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/kerrors"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+type callerContextKeyType string
+
+var (
+	CallerContextKey = callerContextKeyType("CALLER")
+)
+
+var (
+	missingCallerSignature = errors.New("missing caller signature")
+	invalidCallerSignature = errors.New("invalid caller signature")
+	expiredCallerSignature = errors.New("caller signature expired")
+	callerNotAllowed       = errors.New("caller service not allowed")
+)
+
+// Caller is the verified identity of the service calling the RPC server and,
+// if the service authenticated one, the end user it is acting for.
+type Caller struct {
+	Service string
+	User    string
+}
+
+// CallerVerifyMiddleware rejects requests whose caller metadata is missing, not
+// signed with secret along with the request or older than maxSkew, and stores the verified Caller in
+// the context. If allowedServices is non-empty, only those services may call.
+func CallerVerifyMiddleware(secret []byte, maxSkew time.Duration, allowedServices []string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req, resp interface{}) error {
+			caller, err := verifyCallerMetadata(ctx, req, secret, maxSkew, allowedServices)
+			if err != nil {
+				return kerrors.ErrACL.WithCause(err)
+			}
+			return next(context.WithValue(ctx, CallerContextKey, caller), req, resp)
+		}
+	}
+}
+
+func verifyCallerMetadata(ctx context.Context, req interface{}, secret []byte, maxSkew time.Duration, allowedServices []string) (*Caller, error) {
+	service, _ := metainfo.GetValue(ctx, s2s.ServiceMetaKey)
+	user, _ := metainfo.GetValue(ctx, s2s.UserMetaKey)
+	timestamp, _ := metainfo.GetValue(ctx, s2s.TimestampMetaKey)
+	signature, ok := metainfo.GetValue(ctx, s2s.SignatureMetaKey)
+	if !ok || service == "" || timestamp == "" {
+		return nil, missingCallerSignature
+	}
+
+	body, err := s2s.SerializeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	md := s2s.Metadata{
+		Service:   service,
+		User:      user,
+		Method:    rpcinfo.GetRPCInfo(ctx).Invocation().MethodName(),
+		Timestamp: timestamp,
+	}
+	if !s2s.Verify(secret, md, body, signature) {
+		return nil, invalidCallerSignature
+	}
+
+	unixMicro, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, invalidCallerSignature
+	}
+	if skew := GetTimeNow().Sub(time.UnixMicro(unixMicro)); skew > maxSkew || skew < -maxSkew {
+		return nil, expiredCallerSignature
+	}
+
+	if len(allowedServices) > 0 && !Contains(allowedServices, service) {
+		return nil, fmt.Errorf("%w: %s", callerNotAllowed, service)
+	}
+
+	return &Caller{Service: service, User: user}, nil
+}
+
+// GetCaller returns the verified caller of the request, or nil if caller
+// verification is disabled.
+func GetCaller(ctx context.Context) *Caller {
+	caller, _ := ctx.Value(CallerContextKey).(*Caller)
+	return caller
+}
+
+// GetCallerUser returns the end user the request is made on behalf of, or an
+// empty string if there is none.
+func GetCallerUser(ctx context.Context) string {
+	if caller := GetCaller(ctx); caller != nil {
+		return caller.User
+	}
+	return ""
+}
+
+// NewCallerVerifyMiddlewareFromConfig returns the caller verification
+// middleware if a shared secret is configured, nil otherwise.
+func NewCallerVerifyMiddlewareFromConfig(cfg S2SConfig) endpoint.Middleware {
+	if cfg.SharedSecret == "" {
+		return nil
+	}
+	return CallerVerifyMiddleware([]byte(cfg.SharedSecret), cfg.MaxClockSkew, cfg.AllowedCallers)
+}
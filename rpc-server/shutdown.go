@@ -0,0 +1,90 @@
+package main
+
+import (
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/registry"
+	"golang.org/x/exp/slog"
+)
+
+// shuttingDown is set once the server begins shutting down, failing readiness
+// checks from then on.
+var shuttingDown atomic.Bool
+
+// ShutdownConfig controls how the server shuts down on SIGINT or SIGTERM. The
+// server first deregisters itself and keeps serving for Delay, giving clients
+// time to observe the deregistration, then stops accepting connections and
+// waits up to Timeout for in-flight requests to complete.
+type ShutdownConfig struct {
+	Delay   time.Duration `yaml:"delay" env:"SHUTDOWN_DELAY"`
+	Timeout time.Duration `yaml:"timeout" env:"SHUTDOWN_TIMEOUT"`
+}
+
+// DrainingRegistry remembers the registration made through it so that it can
+// be deregistered ahead of the server stopping. Deregistering more than once, or
+// before registering, does nothing. If AdvertiseAddr is set, it is registered in
+// place of the address the server listens on.
+type DrainingRegistry struct {
+	registry.Registry
+	AdvertiseAddr net.Addr
+
+	mu   sync.Mutex
+	info *registry.Info
+}
+
+func (r *DrainingRegistry) Register(info *registry.Info) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.AdvertiseAddr != nil {
+		advertised := *info
+		advertised.Addr = r.AdvertiseAddr
+		info = &advertised
+	}
+	if err := r.Registry.Register(info); err != nil {
+		return err
+	}
+	r.info = info
+	return nil
+}
+
+func (r *DrainingRegistry) Deregister(*registry.Info) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.info == nil {
+		return nil
+	}
+	info := r.info
+	r.info = nil
+	return r.Registry.Deregister(info)
+}
+
+// ExitSignal is a Kitex exit signal that, on SIGINT or SIGTERM, fails readiness
+// checks and deregisters the server, then signals the server to stop once the
+// shutdown delay has passed.
+func (config ShutdownConfig) ExitSignal(r *DrainingRegistry) func() <-chan error {
+	return func() <-chan error {
+		exit := make(chan error, 1)
+		go func() {
+			signals := make(chan os.Signal, 1)
+			signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+			defer signal.Stop(signals)
+
+			sig := <-signals
+			slog.Info("shutting down", "signal", sig.String(), "delay", config.Delay, "timeout", config.Timeout)
+			shuttingDown.Store(true)
+			if err := r.Deregister(nil); err != nil {
+				slog.Error("error deregistering server", "error", err)
+			}
+
+			time.Sleep(config.Delay)
+			exit <- nil
+		}()
+		return exit
+	}
+}
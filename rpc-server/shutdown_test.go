@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/cloudwego/kitex/pkg/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingRegistry struct {
+	registered   int
+	deregistered int
+}
+
+func (r *countingRegistry) Register(info *registry.Info) error {
+	r.registered++
+	return nil
+}
+
+func (r *countingRegistry) Deregister(info *registry.Info) error {
+	r.deregistered++
+	return nil
+}
+
+func TestDrainingRegistry(t *testing.T) {
+	inner := &countingRegistry{}
+	r := &DrainingRegistry{Registry: inner}
+
+	// Deregistering before registering must not reach the underlying registry
+	assert.Nil(t, r.Deregister(nil))
+	assert.Equal(t, 0, inner.deregistered)
+
+	assert.Nil(t, r.Register(&registry.Info{ServiceName: "demo.rpc.server"}))
+	assert.Nil(t, r.Deregister(nil))
+	// Kitex deregisters again when the server stops
+	assert.Nil(t, r.Deregister(&registry.Info{ServiceName: "demo.rpc.server"}))
+	assert.Equal(t, 1, inner.registered)
+	assert.Equal(t, 1, inner.deregistered)
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/common/tracing"
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/cloudwego/kitex/pkg/utils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"gorm.io/gorm"
+)
+
+const tracerName = "github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server"
+
+var tracer = otel.Tracer(tracerName)
+
+// TracingMiddleware continues the trace propagated by the caller and starts a
+// server span for every RPC.
+func TracingMiddleware(next endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, req, resp interface{}) error {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, &tracing.MetainfoCarrier{Ctx: ctx})
+
+		method := rpcinfo.GetRPCInfo(ctx).Invocation().MethodName()
+		ctx, span := tracer.Start(ctx, "IMService/"+method, trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.RPCSystemKey.String("kitex"), semconv.RPCServiceKey.String("IMService"), semconv.RPCMethodKey.String(method)))
+		defer span.End()
+
+		err := next(ctx, req, resp)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return err
+		}
+		if result, ok := resp.(utils.KitexResult); ok {
+			if response, ok := result.GetResult().(codedResponse); ok {
+				span.SetAttributes(attribute.Int("imservice.code", int(response.GetCode())))
+			}
+		}
+		return nil
+	}
+}
+
+const tracingParentContextKey = "tracing:parent_context"
+
+// TracingPlugin is a GORM plugin recording every database query as a span, a
+// child of the span in the context the query was made with.
+type TracingPlugin struct{}
+
+func (p *TracingPlugin) Name() string {
+	return "tracing"
+}
+
+func (p *TracingPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("tracing:before_create", startQuerySpan("create")); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("gorm:create").Register("tracing:after_create", endQuerySpan); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("tracing:before_query", startQuerySpan("query")); err != nil {
+		return err
+	}
+	if err := callbacks.Query().After("gorm:query").Register("tracing:after_query", endQuerySpan); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tracing:before_update", startQuerySpan("update")); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("tracing:after_update", endQuerySpan); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", startQuerySpan("delete")); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", endQuerySpan); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("tracing:before_row", startQuerySpan("row")); err != nil {
+		return err
+	}
+	if err := callbacks.Row().After("gorm:row").Register("tracing:after_row", endQuerySpan); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().Before("gorm:raw").Register("tracing:before_raw", startQuerySpan("raw")); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("gorm:raw").Register("tracing:after_raw", endQuerySpan)
+}
+
+func startQuerySpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		db.InstanceSet(tracingParentContextKey, ctx)
+		db.Statement.Context, _ = tracer.Start(ctx, "gorm."+operation, trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemKey.String(db.Dialector.Name()), semconv.DBSQLTableKey.String(db.Statement.Table)))
+	}
+}
+
+func endQuerySpan(db *gorm.DB) {
+	span := trace.SpanFromContext(db.Statement.Context)
+	if !span.IsRecording() {
+		restoreParentContext(db)
+		return
+	}
+
+	span.SetAttributes(
+		semconv.DBStatementKey.String(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+	span.End()
+	restoreParentContext(db)
+}
+
+// restoreParentContext stops later queries on the same statement from being
+// recorded as children of a finished query span.
+func restoreParentContext(db *gorm.DB) {
+	if ctx, ok := db.InstanceGet(tracingParentContextKey); ok {
+		db.Statement.Context = ctx.(context.Context)
+	}
+}
+
+// useTracingPlugin registers the GORM tracing plugin on db, logging instead of
+// failing if it cannot be registered.
+func useTracingPlugin(db *gorm.DB) {
+	if err := db.Use(&TracingPlugin{}); err != nil {
+		slog.Error("error registering database tracing", "error", err)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/TikTokTechImmersion/assignment_demo_2023/rpc-server/kitex_gen/rpc"
+	"github.com/bytedance/gopkg/cloud/metainfo"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	provider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+	})
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	s := &IMServiceImpl{}
+	if _, err := s.Send(context.Background(), &rpc.SendRequest{
+		Message: &rpc.Message{Chat: "tracing_a:tracing_b", Text: "hi", Sender: "tracing_a", SendTime: GetTimeNow().UnixMicro()},
+	}); err != nil {
+		t.Fatalf("Error when creating test message for tracing test: %+v\n", err)
+	}
+
+	traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := rpcinfo.NewCtxWithRPCInfo(context.Background(), rpcinfo.NewRPCInfo(nil, nil, rpcinfo.NewInvocation("IMService", "Pull"), nil, nil))
+	ctx = metainfo.WithValue(ctx, "TRACEPARENT", traceparent)
+
+	endpoint := TracingMiddleware(func(ctx context.Context, req, resp interface{}) error {
+		result := resp.(*rpc.IMServicePullResult)
+		response, err := s.Pull(ctx, req.(*rpc.IMServicePullArgs).Req)
+		result.Success = response
+		return err
+	})
+	req := &rpc.IMServicePullArgs{Req: &rpc.PullRequest{Chat: "tracing_a:tracing_b", Limit: 10}}
+	assert.Nil(t, endpoint(ctx, req, &rpc.IMServicePullResult{}))
+
+	var serverSpan sdktrace.ReadOnlySpan
+	var querySpans []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			continue
+		}
+		if span.Name() == "IMService/Pull" {
+			serverSpan = span
+		} else if strings.HasPrefix(span.Name(), "gorm.") {
+			querySpans = append(querySpans, span)
+		}
+	}
+
+	if assert.NotNil(t, serverSpan) {
+		assert.Equal(t, "00f067aa0ba902b7", serverSpan.Parent().SpanID().String())
+		assert.NotEmpty(t, querySpans)
+		for _, span := range querySpans {
+			assert.Equal(t, serverSpan.SpanContext().SpanID(), span.Parent().SpanID())
+		}
+	}
+}
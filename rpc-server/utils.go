@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/base64"
 	"errors"
 	"sort"
 	"strings"
@@ -15,6 +16,8 @@ var (
 	invalidChatID   = errors.New("invalid chat id")
 	invalidSender   = errors.New("invalid sender")
 	invalidSendTime = errors.New("invalid send time")
+	invalidUser     = errors.New("invalid user")
+	invalidKey      = errors.New("invalid identity key")
 )
 
 func GetSenderReceiver(message *rpc.Message) (string, string) {
@@ -36,6 +39,11 @@ func GetNormalisedChatID(chatId string) string {
 	return strings.Join(splitChatID, ":")
 }
 
+// IsChatMember reports whether user is one of the members of a "<member1>:<member2>" chat.
+func IsChatMember(chatId string, user string) bool {
+	return Contains(strings.Split(chatId, ":"), user)
+}
+
 func Min[V constraints.Ordered](values ...V) V {
 	if len(values) == 0 {
 		panic("No values provided to Min")
@@ -51,6 +59,15 @@ func Min[V constraints.Ordered](values ...V) V {
 	return result
 }
 
+func Contains[V comparable](values []V, value V) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func ValidateChatID(chatId string) error {
 	splitChatID := strings.Split(chatId, ":")
 	if len(splitChatID) != 2 {
@@ -64,6 +81,50 @@ func ValidateChatID(chatId string) error {
 	return nil
 }
 
+func ValidateUser(user string) error {
+	if user == "" || strings.Contains(user, ":") {
+		return invalidUser
+	}
+	return nil
+}
+
+func ValidateBlock(user string, blocked string) error {
+	if err := ValidateUser(user); err != nil {
+		return err
+	}
+
+	if err := ValidateUser(blocked); err != nil {
+		return err
+	}
+
+	if user == blocked {
+		return invalidUser
+	}
+
+	return nil
+}
+
+func ValidateIdentityKey(key *rpc.IdentityKey) error {
+	if key == nil {
+		return invalidKey
+	}
+
+	if err := ValidateUser(key.GetUser()); err != nil {
+		return err
+	}
+
+	if key.GetKeyId() == "" || key.GetAlgorithm() == "" {
+		return invalidKey
+	}
+
+	publicKey, err := base64.StdEncoding.DecodeString(key.GetPublicKey())
+	if err != nil || len(publicKey) == 0 {
+		return invalidKey
+	}
+
+	return nil
+}
+
 func ValidateMessage(message *rpc.Message) error {
 	if message == nil {
 		return invalidMessage
@@ -78,7 +139,10 @@ func ValidateMessage(message *rpc.Message) error {
 		return invalidSender
 	}
 
-	message.SetText(strings.TrimSpace(message.GetText()))
+	// Encrypted envelopes are opaque and must be stored byte for byte
+	if !message.GetEncrypted() {
+		message.SetText(strings.TrimSpace(message.GetText()))
+	}
 	if message.GetText() == "" {
 		return invalidMessage
 	}
@@ -215,6 +215,17 @@ func TestValidateMessage(t *testing.T) {
 			nil,
 			invalidMessage,
 		},
+		{
+			"empty encrypted message",
+			&rpc.Message{
+				Chat:      "a:b",
+				Text:      "",
+				Sender:    "a",
+				SendTime:  1,
+				Encrypted: b(true),
+			},
+			invalidMessage,
+		},
 	}
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -223,3 +234,56 @@ func TestValidateMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMessage_Normalisation(t *testing.T) {
+	plain := &rpc.Message{Chat: "a:b", Text: "  hi  ", Sender: "a"}
+	assert.Nil(t, ValidateMessage(plain))
+	assert.Equal(t, "hi", plain.GetText(), "expected plain text to be trimmed")
+
+	encrypted := &rpc.Message{Chat: "a:b", Text: "  ciphertext  ", Sender: "a", Encrypted: b(true)}
+	assert.Nil(t, ValidateMessage(encrypted))
+	assert.Equal(t, "  ciphertext  ", encrypted.GetText(), "expected encrypted envelope to be left untouched")
+}
+
+func TestValidateIdentityKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    *rpc.IdentityKey
+		output error
+	}{
+		{"valid key", &rpc.IdentityKey{User: "a", KeyId: "k1", Algorithm: "x25519", PublicKey: "AAEC"}, nil},
+		{"nil key", nil, invalidKey},
+		{"invalid user", &rpc.IdentityKey{User: "a:b", KeyId: "k1", Algorithm: "x25519", PublicKey: "AAEC"}, invalidUser},
+		{"missing key id", &rpc.IdentityKey{User: "a", Algorithm: "x25519", PublicKey: "AAEC"}, invalidKey},
+		{"missing algorithm", &rpc.IdentityKey{User: "a", KeyId: "k1", PublicKey: "AAEC"}, invalidKey},
+		{"empty public key", &rpc.IdentityKey{User: "a", KeyId: "k1", Algorithm: "x25519"}, invalidKey},
+		{"public key not base64", &rpc.IdentityKey{User: "a", KeyId: "k1", Algorithm: "x25519", PublicKey: "not base64!"}, invalidKey},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := ValidateIdentityKey(testCase.key)
+			assert.True(t, errors.Is(err, testCase.output))
+		})
+	}
+}
+
+func TestValidateBlock(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    string
+		blocked string
+		output  error
+	}{
+		{"valid block", "a", "b", nil},
+		{"empty user", "", "b", invalidUser},
+		{"empty blocked", "a", "", invalidUser},
+		{"user with separator", "a:c", "b", invalidUser},
+		{"blocking self", "a", "a", invalidUser},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := ValidateBlock(testCase.user, testCase.blocked)
+			assert.True(t, errors.Is(err, testCase.output))
+		})
+	}
+}